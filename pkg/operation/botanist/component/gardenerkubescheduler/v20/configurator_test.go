@@ -16,6 +16,7 @@ package v20_test
 
 import (
 	"testing"
+	"time"
 
 	v20 "github.com/gardener/gardener/pkg/operation/botanist/component/gardenerkubescheduler/v20"
 	"github.com/gardener/gardener/third_party/kube-scheduler/v20/v1beta1"
@@ -37,6 +38,82 @@ var _ = Describe("NewConfigurator", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(c).NotTo(BeNil())
 	})
+
+	It("should apply custom leader election timing options", func() {
+		config := &v1beta1.KubeSchedulerConfiguration{}
+		c, err := v20.NewConfigurator("baz", "test", config, v20.WithLeaseDuration(30*time.Second), v20.WithRenewDeadline(20*time.Second), v20.WithRetryPeriod(5*time.Second))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+		Expect(config.LeaderElection.LeaseDuration.Duration).To(Equal(30 * time.Second))
+		Expect(config.LeaderElection.RenewDeadline.Duration).To(Equal(20 * time.Second))
+		Expect(config.LeaderElection.RetryPeriod.Duration).To(Equal(5 * time.Second))
+		Expect(*config.LeaderElection.LeaderElect).To(BeTrue())
+		Expect(config.LeaderElection.ResourceName).To(Equal("baz"))
+		Expect(config.LeaderElection.ResourceNamespace).To(Equal("test"))
+	})
+
+	It("should disable leader election and omit the resource name/namespace", func() {
+		config := &v1beta1.KubeSchedulerConfiguration{}
+		c, err := v20.NewConfigurator("baz", "test", config, v20.WithLeaderElectionDisabled())
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+		Expect(*config.LeaderElection.LeaderElect).To(BeFalse())
+		Expect(config.LeaderElection.ResourceName).To(BeEmpty())
+		Expect(config.LeaderElection.ResourceNamespace).To(BeEmpty())
+	})
+
+	It("should apply client connection overrides", func() {
+		config := &v1beta1.KubeSchedulerConfiguration{}
+		c, err := v20.NewConfigurator("baz", "test", config,
+			v20.WithClientConnectionQPS(50),
+			v20.WithClientConnectionBurst(100),
+			v20.WithClientConnectionContentType("application/vnd.kubernetes.protobuf"),
+			v20.WithClientConnectionAcceptContentTypes("application/vnd.kubernetes.protobuf,application/json"),
+		)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+		Expect(config.ClientConnection.QPS).To(Equal(float32(50)))
+		Expect(config.ClientConnection.Burst).To(Equal(int32(100)))
+		Expect(config.ClientConnection.ContentType).To(Equal("application/vnd.kubernetes.protobuf"))
+		Expect(config.ClientConnection.AcceptContentTypes).To(Equal("application/vnd.kubernetes.protobuf,application/json"))
+	})
+
+	It("should preserve the empty client connection defaults when no override is given", func() {
+		config := &v1beta1.KubeSchedulerConfiguration{}
+		c, err := v20.NewConfigurator("baz", "test", config)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+		Expect(config.ClientConnection.QPS).To(BeZero())
+		Expect(config.ClientConnection.Burst).To(BeZero())
+		Expect(config.ClientConnection.ContentType).To(BeEmpty())
+		Expect(config.ClientConnection.AcceptContentTypes).To(BeEmpty())
+	})
+
+	It("should apply the percentageOfNodesToScore and parallelism overrides", func() {
+		config := &v1beta1.KubeSchedulerConfiguration{}
+		c, err := v20.NewConfigurator("baz", "test", config, v20.WithPercentageOfNodesToScore(30), v20.WithParallelism(8))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+		Expect(*config.PercentageOfNodesToScore).To(Equal(int32(30)))
+		Expect(*config.Parallelism).To(Equal(int32(8)))
+	})
+
+	It("should return an error if percentageOfNodesToScore is out of the 0-100 range", func() {
+		c, err := v20.NewConfigurator("baz", "test", &v1beta1.KubeSchedulerConfiguration{}, v20.WithPercentageOfNodesToScore(101))
+		Expect(err).To(MatchError(ContainSubstring("percentageOfNodesToScore must be between 0 and 100")))
+		Expect(c).To(BeNil())
+	})
+
+	It("should return an error if parallelism is not positive", func() {
+		c, err := v20.NewConfigurator("baz", "test", &v1beta1.KubeSchedulerConfiguration{}, v20.WithParallelism(0))
+		Expect(err).To(MatchError(ContainSubstring("parallelism must be greater than 0")))
+		Expect(c).To(BeNil())
+	})
 })
 
 var _ = Describe("Config", func() {
@@ -34,22 +34,129 @@ type v21Configurator struct {
 	codec  serializer.CodecFactory
 }
 
+// ConfiguratorOption configures optional behavior of NewConfigurator.
+type ConfiguratorOption func(*configuratorOptions)
+
+type configuratorOptions struct {
+	leaseDuration                      time.Duration
+	renewDeadline                      time.Duration
+	retryPeriod                        time.Duration
+	disableLeaderElection              bool
+	clientConnectionQPS                *float32
+	clientConnectionBurst              *int32
+	clientConnectionContentType        *string
+	clientConnectionAcceptContentTypes *string
+	percentageOfNodesToScore           *int32
+	parallelism                        *int32
+}
+
+// WithLeaseDuration overrides the leader election lease duration (default: 15s).
+func WithLeaseDuration(d time.Duration) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.leaseDuration = d }
+}
+
+// WithRenewDeadline overrides the leader election renew deadline (default: 10s).
+func WithRenewDeadline(d time.Duration) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.renewDeadline = d }
+}
+
+// WithRetryPeriod overrides the leader election retry period (default: 2s).
+func WithRetryPeriod(d time.Duration) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.retryPeriod = d }
+}
+
+// WithLeaderElectionDisabled disables leader election entirely. The resource lock's name and namespace are omitted
+// since they are meaningless without leader election.
+func WithLeaderElectionDisabled() ConfiguratorOption {
+	return func(o *configuratorOptions) { o.disableLeaderElection = true }
+}
+
+// WithClientConnectionQPS overrides the scheduler client's QPS (default: 0, i.e. client-go's built-in default).
+func WithClientConnectionQPS(qps float32) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.clientConnectionQPS = &qps }
+}
+
+// WithClientConnectionBurst overrides the scheduler client's burst (default: 0, i.e. client-go's built-in default).
+func WithClientConnectionBurst(burst int32) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.clientConnectionBurst = &burst }
+}
+
+// WithClientConnectionContentType overrides the content type used when sending data to the API server.
+func WithClientConnectionContentType(contentType string) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.clientConnectionContentType = &contentType }
+}
+
+// WithClientConnectionAcceptContentTypes overrides the Accept header sent by the scheduler client.
+func WithClientConnectionAcceptContentTypes(acceptContentTypes string) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.clientConnectionAcceptContentTypes = &acceptContentTypes }
+}
+
+// WithPercentageOfNodesToScore overrides the percentage of all feasible nodes the scheduler scores before picking one
+// (default: 0, i.e. the scheduler's own size-based default). Must be between 0 and 100; NewConfigurator returns an
+// error otherwise.
+func WithPercentageOfNodesToScore(percentage int32) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.percentageOfNodesToScore = &percentage }
+}
+
+// WithParallelism overrides the amount of parallelism used by the scheduling algorithms (default: 0, i.e. the
+// scheduler's own default of 16). Must be greater than 0; NewConfigurator returns an error otherwise.
+func WithParallelism(parallelism int32) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.parallelism = &parallelism }
+}
+
 // NewConfigurator creates a Configurator for Kubernetes version 1.21.
-func NewConfigurator(resourceName, namespace string, config *schedulerv21v1beta1.KubeSchedulerConfiguration) (configurator.Configurator, error) {
+func NewConfigurator(resourceName, namespace string, config *schedulerv21v1beta1.KubeSchedulerConfiguration, opts ...ConfiguratorOption) (configurator.Configurator, error) {
+	options := &configuratorOptions{
+		leaseDuration: 15 * time.Second,
+		renewDeadline: 10 * time.Second,
+		retryPeriod:   2 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := validateSchedulingTuning(options.percentageOfNodesToScore, options.parallelism); err != nil {
+		return nil, err
+	}
+
 	scheme := runtime.NewScheme()
 
 	if err := schedulerv21v1beta1.AddToScheme(scheme); err != nil {
 		return nil, err
 	}
 
-	config.LeaderElection = componentbaseconfigv1alpha1.LeaderElectionConfiguration{
-		LeaseDuration:     metav1.Duration{Duration: 15 * time.Second},
-		RenewDeadline:     metav1.Duration{Duration: 10 * time.Second},
-		RetryPeriod:       metav1.Duration{Duration: 2 * time.Second},
-		ResourceLock:      "leases",
-		ResourceName:      resourceName,
-		LeaderElect:       pointer.Bool(true),
-		ResourceNamespace: namespace,
+	leaderElection := componentbaseconfigv1alpha1.LeaderElectionConfiguration{
+		LeaseDuration: metav1.Duration{Duration: options.leaseDuration},
+		RenewDeadline: metav1.Duration{Duration: options.renewDeadline},
+		RetryPeriod:   metav1.Duration{Duration: options.retryPeriod},
+		ResourceLock:  "leases",
+		LeaderElect:   pointer.Bool(!options.disableLeaderElection),
+	}
+	if !options.disableLeaderElection {
+		leaderElection.ResourceName = resourceName
+		leaderElection.ResourceNamespace = namespace
+	}
+
+	config.LeaderElection = leaderElection
+
+	if options.clientConnectionQPS != nil {
+		config.ClientConnection.QPS = *options.clientConnectionQPS
+	}
+	if options.clientConnectionBurst != nil {
+		config.ClientConnection.Burst = *options.clientConnectionBurst
+	}
+	if options.clientConnectionContentType != nil {
+		config.ClientConnection.ContentType = *options.clientConnectionContentType
+	}
+	if options.clientConnectionAcceptContentTypes != nil {
+		config.ClientConnection.AcceptContentTypes = *options.clientConnectionAcceptContentTypes
+	}
+
+	if options.percentageOfNodesToScore != nil {
+		config.PercentageOfNodesToScore = options.percentageOfNodesToScore
+	}
+	if options.parallelism != nil {
+		config.Parallelism = options.parallelism
 	}
 
 	return &v21Configurator{
@@ -76,3 +183,17 @@ func (c *v21Configurator) Config() (string, error) {
 
 	return componentConfigYAML.String(), nil
 }
+
+// validateSchedulingTuning returns an error if percentageOfNodesToScore is set but outside the 0-100 range, or if
+// parallelism is set but not positive.
+func validateSchedulingTuning(percentageOfNodesToScore, parallelism *int32) error {
+	if percentageOfNodesToScore != nil && (*percentageOfNodesToScore < 0 || *percentageOfNodesToScore > 100) {
+		return fmt.Errorf("percentageOfNodesToScore must be between 0 and 100, got %d", *percentageOfNodesToScore)
+	}
+
+	if parallelism != nil && *parallelism <= 0 {
+		return fmt.Errorf("parallelism must be greater than 0, got %d", *parallelism)
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ package v23
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -25,6 +26,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
 	"k8s.io/utils/pointer"
 )
@@ -34,22 +37,165 @@ type v23Configurator struct {
 	codec  serializer.CodecFactory
 }
 
+// JSONConfigurator is implemented by configurators which can additionally render their configuration as indented
+// JSON instead of YAML.
+type JSONConfigurator interface {
+	ConfigJSON() (string, error)
+}
+
+var _ JSONConfigurator = &v23Configurator{}
+
+// ConfiguratorOption configures optional behavior of NewConfigurator.
+type ConfiguratorOption func(*configuratorOptions)
+
+type configuratorOptions struct {
+	leaseDuration                      time.Duration
+	renewDeadline                      time.Duration
+	retryPeriod                        time.Duration
+	disableLeaderElection              bool
+	clientConnectionQPS                *float32
+	clientConnectionBurst              *int32
+	clientConnectionContentType        *string
+	clientConnectionAcceptContentTypes *string
+	percentageOfNodesToScore           *int32
+	parallelism                        *int32
+}
+
+// WithLeaseDuration overrides the leader election lease duration (default: 15s).
+func WithLeaseDuration(d time.Duration) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.leaseDuration = d }
+}
+
+// WithRenewDeadline overrides the leader election renew deadline (default: 10s).
+func WithRenewDeadline(d time.Duration) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.renewDeadline = d }
+}
+
+// WithRetryPeriod overrides the leader election retry period (default: 2s).
+func WithRetryPeriod(d time.Duration) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.retryPeriod = d }
+}
+
+// WithLeaderElectionDisabled disables leader election entirely. The resource lock's name and namespace are omitted
+// since they are meaningless without leader election.
+func WithLeaderElectionDisabled() ConfiguratorOption {
+	return func(o *configuratorOptions) { o.disableLeaderElection = true }
+}
+
+// WithClientConnectionQPS overrides the scheduler client's QPS (default: 0, i.e. client-go's built-in default).
+func WithClientConnectionQPS(qps float32) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.clientConnectionQPS = &qps }
+}
+
+// WithClientConnectionBurst overrides the scheduler client's burst (default: 0, i.e. client-go's built-in default).
+func WithClientConnectionBurst(burst int32) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.clientConnectionBurst = &burst }
+}
+
+// WithClientConnectionContentType overrides the content type used when sending data to the API server.
+func WithClientConnectionContentType(contentType string) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.clientConnectionContentType = &contentType }
+}
+
+// WithClientConnectionAcceptContentTypes overrides the Accept header sent by the scheduler client.
+func WithClientConnectionAcceptContentTypes(acceptContentTypes string) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.clientConnectionAcceptContentTypes = &acceptContentTypes }
+}
+
+// WithPercentageOfNodesToScore overrides the percentage of all feasible nodes the scheduler scores before picking one
+// (default: 0, i.e. the scheduler's own size-based default). Must be between 0 and 100; NewConfigurator returns an
+// error otherwise.
+func WithPercentageOfNodesToScore(percentage int32) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.percentageOfNodesToScore = &percentage }
+}
+
+// WithParallelism overrides the amount of parallelism used by the scheduling algorithms (default: 0, i.e. the
+// scheduler's own default of 16). Must be greater than 0; NewConfigurator returns an error otherwise.
+func WithParallelism(parallelism int32) ConfiguratorOption {
+	return func(o *configuratorOptions) { o.parallelism = &parallelism }
+}
+
+// DefaultConfiguration returns a schedulerv23v1beta3.KubeSchedulerConfiguration with its TypeMeta set, passed through
+// the API scheme's Default. The schedulerv23v1beta3 scheme registers no defaulting functions, so no other field is
+// defaulted: the result does not carry Kubernetes' own defaults (e.g. parallelism or a default profile).
+// The leader election resource lock's name and namespace are pre-populated from the given values, so that callers
+// only need to override the fields relevant to them before passing the result into NewConfigurator, which
+// reconciles the leader election settings once more based on its own resourceName/namespace arguments and options.
+func DefaultConfiguration(name, namespace string) *schedulerv23v1beta3.KubeSchedulerConfiguration {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(schedulerv23v1beta3.AddToScheme(scheme))
+
+	config := &schedulerv23v1beta3.KubeSchedulerConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: schedulerv23v1beta3.SchemeGroupVersion.String(),
+			Kind:       "KubeSchedulerConfiguration",
+		},
+	}
+	config.LeaderElection.ResourceName = name
+	config.LeaderElection.ResourceNamespace = namespace
+
+	scheme.Default(config)
+
+	return config
+}
+
 // NewConfigurator creates a Configurator for Kubernetes version 1.23.
-func NewConfigurator(resourceName, namespace string, config *schedulerv23v1beta3.KubeSchedulerConfiguration) (configurator.Configurator, error) {
+func NewConfigurator(resourceName, namespace string, config *schedulerv23v1beta3.KubeSchedulerConfiguration, opts ...ConfiguratorOption) (configurator.Configurator, error) {
+	options := &configuratorOptions{
+		leaseDuration: 15 * time.Second,
+		renewDeadline: 10 * time.Second,
+		retryPeriod:   2 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := validateUniqueSchedulerNames(config.Profiles); err != nil {
+		return nil, err
+	}
+
+	if err := validateSchedulingTuning(options.percentageOfNodesToScore, options.parallelism); err != nil {
+		return nil, err
+	}
+
 	scheme := runtime.NewScheme()
 
 	if err := schedulerv23v1beta3.AddToScheme(scheme); err != nil {
 		return nil, err
 	}
 
-	config.LeaderElection = componentbaseconfigv1alpha1.LeaderElectionConfiguration{
-		LeaseDuration:     metav1.Duration{Duration: 15 * time.Second},
-		RenewDeadline:     metav1.Duration{Duration: 10 * time.Second},
-		RetryPeriod:       metav1.Duration{Duration: 2 * time.Second},
-		ResourceLock:      "leases",
-		ResourceName:      resourceName,
-		LeaderElect:       pointer.Bool(true),
-		ResourceNamespace: namespace,
+	leaderElection := componentbaseconfigv1alpha1.LeaderElectionConfiguration{
+		LeaseDuration: metav1.Duration{Duration: options.leaseDuration},
+		RenewDeadline: metav1.Duration{Duration: options.renewDeadline},
+		RetryPeriod:   metav1.Duration{Duration: options.retryPeriod},
+		ResourceLock:  "leases",
+		LeaderElect:   pointer.Bool(!options.disableLeaderElection),
+	}
+	if !options.disableLeaderElection {
+		leaderElection.ResourceName = resourceName
+		leaderElection.ResourceNamespace = namespace
+	}
+
+	config.LeaderElection = leaderElection
+
+	if options.clientConnectionQPS != nil {
+		config.ClientConnection.QPS = *options.clientConnectionQPS
+	}
+	if options.clientConnectionBurst != nil {
+		config.ClientConnection.Burst = *options.clientConnectionBurst
+	}
+	if options.clientConnectionContentType != nil {
+		config.ClientConnection.ContentType = *options.clientConnectionContentType
+	}
+	if options.clientConnectionAcceptContentTypes != nil {
+		config.ClientConnection.AcceptContentTypes = *options.clientConnectionAcceptContentTypes
+	}
+
+	if options.percentageOfNodesToScore != nil {
+		config.PercentageOfNodesToScore = options.percentageOfNodesToScore
+	}
+	if options.parallelism != nil {
+		config.Parallelism = options.parallelism
 	}
 
 	return &v23Configurator{
@@ -58,6 +204,105 @@ func NewConfigurator(resourceName, namespace string, config *schedulerv23v1beta3
 	}, nil
 }
 
+// AppendDerivedProfile deep-copies the given base profile, overrides its SchedulerName with the given value, and
+// appends the result to profiles. This is useful for adding an additional profile (e.g. for a second, custom
+// scheduler name) that should otherwise start out identical to an existing one.
+func AppendDerivedProfile(profiles []schedulerv23v1beta3.KubeSchedulerProfile, base schedulerv23v1beta3.KubeSchedulerProfile, schedulerName string) []schedulerv23v1beta3.KubeSchedulerProfile {
+	derived := *base.DeepCopy()
+	derived.SchedulerName = &schedulerName
+
+	return append(profiles, derived)
+}
+
+// validateUniqueSchedulerNames returns an error if two or more profiles specify the same scheduler name.
+func validateUniqueSchedulerNames(profiles []schedulerv23v1beta3.KubeSchedulerProfile) error {
+	schedulerNames := sets.NewString()
+
+	for _, profile := range profiles {
+		if profile.SchedulerName == nil {
+			continue
+		}
+
+		if schedulerNames.Has(*profile.SchedulerName) {
+			return fmt.Errorf("duplicate scheduler name %q in profiles", *profile.SchedulerName)
+		}
+		schedulerNames.Insert(*profile.SchedulerName)
+	}
+
+	return nil
+}
+
+// validateSchedulingTuning returns an error if percentageOfNodesToScore is set but outside the 0-100 range, or if
+// parallelism is set but not positive.
+func validateSchedulingTuning(percentageOfNodesToScore, parallelism *int32) error {
+	if percentageOfNodesToScore != nil && (*percentageOfNodesToScore < 0 || *percentageOfNodesToScore > 100) {
+		return fmt.Errorf("percentageOfNodesToScore must be between 0 and 100, got %d", *percentageOfNodesToScore)
+	}
+
+	if parallelism != nil && *parallelism <= 0 {
+		return fmt.Errorf("parallelism must be greater than 0, got %d", *parallelism)
+	}
+
+	return nil
+}
+
+// MergePluginsIntoProfile merges the given plugins into the profile with the given scheduler name. Extension points
+// already configured on the profile are left untouched; only extension points which are unset (i.e. have no
+// enabled and no disabled plugins) on the profile are populated with the ones from the given plugins. If no profile
+// with the given scheduler name exists, a new one is appended.
+func MergePluginsIntoProfile(profiles []schedulerv23v1beta3.KubeSchedulerProfile, schedulerName string, plugins *schedulerv23v1beta3.Plugins) []schedulerv23v1beta3.KubeSchedulerProfile {
+	if plugins == nil {
+		return profiles
+	}
+
+	for i, profile := range profiles {
+		if profile.SchedulerName == nil || *profile.SchedulerName != schedulerName {
+			continue
+		}
+
+		if profile.Plugins == nil {
+			profiles[i].Plugins = plugins
+			return profiles
+		}
+
+		profiles[i].Plugins = mergePlugins(profile.Plugins, plugins)
+		return profiles
+	}
+
+	return append(profiles, schedulerv23v1beta3.KubeSchedulerProfile{
+		SchedulerName: &schedulerName,
+		Plugins:       plugins,
+	})
+}
+
+func mergePlugins(existing, additional *schedulerv23v1beta3.Plugins) *schedulerv23v1beta3.Plugins {
+	merged := existing.DeepCopy()
+
+	for _, pair := range []struct {
+		dst *schedulerv23v1beta3.PluginSet
+		src schedulerv23v1beta3.PluginSet
+	}{
+		{&merged.QueueSort, additional.QueueSort},
+		{&merged.PreFilter, additional.PreFilter},
+		{&merged.Filter, additional.Filter},
+		{&merged.PostFilter, additional.PostFilter},
+		{&merged.PreScore, additional.PreScore},
+		{&merged.Score, additional.Score},
+		{&merged.Reserve, additional.Reserve},
+		{&merged.Permit, additional.Permit},
+		{&merged.PreBind, additional.PreBind},
+		{&merged.Bind, additional.Bind},
+		{&merged.PostBind, additional.PostBind},
+		{&merged.MultiPoint, additional.MultiPoint},
+	} {
+		if len(pair.dst.Enabled) == 0 && len(pair.dst.Disabled) == 0 {
+			*pair.dst = pair.src
+		}
+	}
+
+	return merged
+}
+
 func (c *v23Configurator) Config() (string, error) {
 	const mediaType = runtime.ContentTypeYAML
 
@@ -76,3 +321,27 @@ func (c *v23Configurator) Config() (string, error) {
 
 	return componentConfigYAML.String(), nil
 }
+
+// ConfigJSON returns the same KubeSchedulerConfiguration as Config, but serialized as indented JSON instead of YAML.
+func (c *v23Configurator) ConfigJSON() (string, error) {
+	const mediaType = runtime.ContentTypeJSON
+
+	info, ok := runtime.SerializerInfoForMediaType(c.codec.SupportedMediaTypes(), mediaType)
+	if !ok {
+		return "", fmt.Errorf("unable to locate encoder -- %q is not a supported media type", mediaType)
+	}
+
+	encoder := c.codec.EncoderForVersion(info.Serializer, schedulerv23v1beta3.SchemeGroupVersion)
+
+	componentConfigJSON := &bytes.Buffer{}
+	if err := encoder.Encode(c.config, componentConfigJSON); err != nil {
+		return "", err
+	}
+
+	indentedComponentConfigJSON := &bytes.Buffer{}
+	if err := json.Indent(indentedComponentConfigJSON, componentConfigJSON.Bytes(), "", "  "); err != nil {
+		return "", err
+	}
+
+	return indentedComponentConfigJSON.String(), nil
+}
@@ -15,13 +15,16 @@
 package v23_test
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	v23 "github.com/gardener/gardener/pkg/operation/botanist/component/gardenerkubescheduler/v23"
 	"github.com/gardener/gardener/third_party/kube-scheduler/v23/v1beta3"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 )
 
@@ -37,6 +40,204 @@ var _ = Describe("NewConfigurator", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(c).NotTo(BeNil())
 	})
+
+	It("should apply custom leader election timing options", func() {
+		config := &v1beta3.KubeSchedulerConfiguration{}
+		c, err := v23.NewConfigurator("baz", "test", config, v23.WithLeaseDuration(30*time.Second), v23.WithRenewDeadline(20*time.Second), v23.WithRetryPeriod(5*time.Second))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+		Expect(config.LeaderElection.LeaseDuration.Duration).To(Equal(30 * time.Second))
+		Expect(config.LeaderElection.RenewDeadline.Duration).To(Equal(20 * time.Second))
+		Expect(config.LeaderElection.RetryPeriod.Duration).To(Equal(5 * time.Second))
+		Expect(*config.LeaderElection.LeaderElect).To(BeTrue())
+		Expect(config.LeaderElection.ResourceName).To(Equal("baz"))
+		Expect(config.LeaderElection.ResourceNamespace).To(Equal("test"))
+	})
+
+	It("should disable leader election and omit the resource name/namespace", func() {
+		config := &v1beta3.KubeSchedulerConfiguration{}
+		c, err := v23.NewConfigurator("baz", "test", config, v23.WithLeaderElectionDisabled())
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+		Expect(*config.LeaderElection.LeaderElect).To(BeFalse())
+		Expect(config.LeaderElection.ResourceName).To(BeEmpty())
+		Expect(config.LeaderElection.ResourceNamespace).To(BeEmpty())
+	})
+
+	It("should apply client connection overrides", func() {
+		config := &v1beta3.KubeSchedulerConfiguration{}
+		c, err := v23.NewConfigurator("baz", "test", config,
+			v23.WithClientConnectionQPS(50),
+			v23.WithClientConnectionBurst(100),
+			v23.WithClientConnectionContentType("application/vnd.kubernetes.protobuf"),
+			v23.WithClientConnectionAcceptContentTypes("application/vnd.kubernetes.protobuf,application/json"),
+		)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+		Expect(config.ClientConnection.QPS).To(Equal(float32(50)))
+		Expect(config.ClientConnection.Burst).To(Equal(int32(100)))
+		Expect(config.ClientConnection.ContentType).To(Equal("application/vnd.kubernetes.protobuf"))
+		Expect(config.ClientConnection.AcceptContentTypes).To(Equal("application/vnd.kubernetes.protobuf,application/json"))
+	})
+
+	It("should preserve the empty client connection defaults when no override is given", func() {
+		config := &v1beta3.KubeSchedulerConfiguration{}
+		c, err := v23.NewConfigurator("baz", "test", config)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+		Expect(config.ClientConnection.QPS).To(BeZero())
+		Expect(config.ClientConnection.Burst).To(BeZero())
+		Expect(config.ClientConnection.ContentType).To(BeEmpty())
+		Expect(config.ClientConnection.AcceptContentTypes).To(BeEmpty())
+	})
+
+	It("should apply the percentageOfNodesToScore and parallelism overrides", func() {
+		config := &v1beta3.KubeSchedulerConfiguration{}
+		c, err := v23.NewConfigurator("baz", "test", config, v23.WithPercentageOfNodesToScore(30), v23.WithParallelism(8))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+		Expect(*config.PercentageOfNodesToScore).To(Equal(int32(30)))
+		Expect(*config.Parallelism).To(Equal(int32(8)))
+	})
+
+	It("should return an error if percentageOfNodesToScore is out of the 0-100 range", func() {
+		c, err := v23.NewConfigurator("baz", "test", &v1beta3.KubeSchedulerConfiguration{}, v23.WithPercentageOfNodesToScore(101))
+		Expect(err).To(MatchError(ContainSubstring("percentageOfNodesToScore must be between 0 and 100")))
+		Expect(c).To(BeNil())
+
+		c, err = v23.NewConfigurator("baz", "test", &v1beta3.KubeSchedulerConfiguration{}, v23.WithPercentageOfNodesToScore(-1))
+		Expect(err).To(MatchError(ContainSubstring("percentageOfNodesToScore must be between 0 and 100")))
+		Expect(c).To(BeNil())
+	})
+
+	It("should return an error if parallelism is not positive", func() {
+		c, err := v23.NewConfigurator("baz", "test", &v1beta3.KubeSchedulerConfiguration{}, v23.WithParallelism(0))
+		Expect(err).To(MatchError(ContainSubstring("parallelism must be greater than 0")))
+		Expect(c).To(BeNil())
+	})
+
+	It("should return an error if two profiles specify the same scheduler name", func() {
+		config := &v1beta3.KubeSchedulerConfiguration{
+			Profiles: []v1beta3.KubeSchedulerProfile{
+				{SchedulerName: pointer.String("default-scheduler")},
+				{SchedulerName: pointer.String("default-scheduler")},
+			},
+		}
+
+		c, err := v23.NewConfigurator("baz", "test", config)
+		Expect(err).To(MatchError(ContainSubstring(`duplicate scheduler name "default-scheduler"`)))
+		Expect(c).To(BeNil())
+	})
+
+	It("should not return an error if multiple profiles specify distinct scheduler names", func() {
+		config := &v1beta3.KubeSchedulerConfiguration{
+			Profiles: []v1beta3.KubeSchedulerProfile{
+				{SchedulerName: pointer.String("default-scheduler")},
+				{SchedulerName: pointer.String("custom-scheduler")},
+			},
+		}
+
+		c, err := v23.NewConfigurator("baz", "test", config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+	})
+})
+
+var _ = Describe("DefaultConfiguration", func() {
+	It("should set the TypeMeta and produce a configuration that NewConfigurator serializes identically to a plain configuration", func() {
+		defaultConfig := v23.DefaultConfiguration("baz", "test")
+		Expect(defaultConfig.APIVersion).To(Equal("kubescheduler.config.k8s.io/v1beta3"))
+		Expect(defaultConfig.Kind).To(Equal("KubeSchedulerConfiguration"))
+
+		c, err := v23.NewConfigurator("baz", "test", defaultConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		defaultConfigYAML, err := c.Config()
+		Expect(err).NotTo(HaveOccurred())
+
+		plainConfig := &v1beta3.KubeSchedulerConfiguration{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "kubescheduler.config.k8s.io/v1beta3",
+				Kind:       "KubeSchedulerConfiguration",
+			},
+		}
+		plainC, err := v23.NewConfigurator("baz", "test", plainConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		plainConfigYAML, err := plainC.Config()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(defaultConfigYAML).To(Equal(plainConfigYAML))
+	})
+})
+
+var _ = Describe("MergePluginsIntoProfile", func() {
+	It("should populate unset extension points of an existing profile", func() {
+		profiles := []v1beta3.KubeSchedulerProfile{
+			{
+				SchedulerName: pointer.String("test"),
+				Plugins: &v1beta3.Plugins{
+					Score: v1beta3.PluginSet{Enabled: []v1beta3.Plugin{{Name: "NodeResourcesMostAllocated"}}},
+				},
+			},
+		}
+
+		merged := v23.MergePluginsIntoProfile(profiles, "test", &v1beta3.Plugins{
+			Score:  v1beta3.PluginSet{Disabled: []v1beta3.Plugin{{Name: "NodeResourcesLeastAllocated"}}},
+			Filter: v1beta3.PluginSet{Enabled: []v1beta3.Plugin{{Name: "NodeUnschedulable"}}},
+		})
+
+		Expect(merged).To(HaveLen(1))
+		Expect(merged[0].Plugins.Score.Enabled).To(ConsistOf(v1beta3.Plugin{Name: "NodeResourcesMostAllocated"}))
+		Expect(merged[0].Plugins.Score.Disabled).To(BeEmpty())
+		Expect(merged[0].Plugins.Filter.Enabled).To(ConsistOf(v1beta3.Plugin{Name: "NodeUnschedulable"}))
+	})
+
+	It("should append a new profile if none matches the scheduler name", func() {
+		merged := v23.MergePluginsIntoProfile(nil, "test", &v1beta3.Plugins{
+			Score: v1beta3.PluginSet{Enabled: []v1beta3.Plugin{{Name: "NodeResourcesMostAllocated"}}},
+		})
+
+		Expect(merged).To(HaveLen(1))
+		Expect(*merged[0].SchedulerName).To(Equal("test"))
+	})
+})
+
+var _ = Describe("AppendDerivedProfile", func() {
+	It("should append a profile derived from the base profile with the overridden scheduler name", func() {
+		base := v1beta3.KubeSchedulerProfile{
+			SchedulerName: pointer.String("default-scheduler"),
+			Plugins: &v1beta3.Plugins{
+				Score: v1beta3.PluginSet{Enabled: []v1beta3.Plugin{{Name: "NodeResourcesMostAllocated"}}},
+			},
+		}
+
+		profiles := v23.AppendDerivedProfile([]v1beta3.KubeSchedulerProfile{base}, base, "custom-scheduler")
+
+		Expect(profiles).To(HaveLen(2))
+		Expect(profiles[0]).To(Equal(base))
+		Expect(*profiles[1].SchedulerName).To(Equal("custom-scheduler"))
+		Expect(profiles[1].Plugins).To(Equal(base.Plugins))
+	})
+
+	It("should not mutate the base profile's plugins when the derived profile's plugins are changed", func() {
+		base := v1beta3.KubeSchedulerProfile{
+			SchedulerName: pointer.String("default-scheduler"),
+			Plugins: &v1beta3.Plugins{
+				Score: v1beta3.PluginSet{Enabled: []v1beta3.Plugin{{Name: "NodeResourcesMostAllocated"}}},
+			},
+		}
+
+		profiles := v23.AppendDerivedProfile(nil, base, "custom-scheduler")
+		profiles[0].Plugins.Score.Enabled[0].Name = "NodeResourcesLeastAllocated"
+
+		Expect(base.Plugins.Score.Enabled[0].Name).To(Equal("NodeResourcesMostAllocated"))
+	})
 })
 
 var _ = Describe("Config", func() {
@@ -79,4 +280,55 @@ profiles:
 - schedulerName: test
 `))
 	})
+
+	It("returns the equivalent config as indented JSON", func() {
+		c, err := v23.NewConfigurator("baz", "test", &v1beta3.KubeSchedulerConfiguration{
+			Profiles: []v1beta3.KubeSchedulerProfile{
+				{
+					SchedulerName: pointer.String("test"),
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		jsonConfigurator, ok := c.(v23.JSONConfigurator)
+		Expect(ok).To(BeTrue())
+
+		configJSON, err := jsonConfigurator.ConfigJSON()
+		Expect(err).NotTo(HaveOccurred())
+
+		var roundTripped v1beta3.KubeSchedulerConfiguration
+		Expect(json.Unmarshal([]byte(configJSON), &roundTripped)).To(Succeed())
+		Expect(roundTripped.Profiles).To(Equal([]v1beta3.KubeSchedulerProfile{{SchedulerName: pointer.String("test")}}))
+		Expect(roundTripped.LeaderElection.ResourceName).To(Equal("baz"))
+		Expect(roundTripped.LeaderElection.ResourceNamespace).To(Equal("test"))
+
+		Expect(configJSON).To(ContainSubstring("\n  \""))
+	})
+
+	It("serializes multiple profiles in the given order", func() {
+		c, err := v23.NewConfigurator("baz", "test", &v1beta3.KubeSchedulerConfiguration{
+			Profiles: []v1beta3.KubeSchedulerProfile{
+				{SchedulerName: pointer.String("default-scheduler")},
+				{SchedulerName: pointer.String("custom-scheduler")},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		output, err := c.Config()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(output).To(ContainSubstring("profiles:\n- schedulerName: default-scheduler\n- schedulerName: custom-scheduler\n"))
+	})
+
+	It("renders the tuned percentageOfNodesToScore and parallelism", func() {
+		c, err := v23.NewConfigurator("baz", "test", &v1beta3.KubeSchedulerConfiguration{}, v23.WithPercentageOfNodesToScore(30), v23.WithParallelism(8))
+		Expect(err).NotTo(HaveOccurred())
+
+		output, err := c.Config()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(output).To(ContainSubstring("percentageOfNodesToScore: 30\n"))
+		Expect(output).To(ContainSubstring("parallelism: 8\n"))
+	})
 })
@@ -0,0 +1,251 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v23
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gardener/gardener/pkg/operation/botanist/component/gardenerkubescheduler/configurator"
+	schedulerv23v1beta2 "github.com/gardener/gardener/third_party/kube-scheduler/v23/v1beta2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
+	"k8s.io/utils/pointer"
+)
+
+// v23ConfiguratorV1beta2 is the kubescheduler.config.k8s.io/v1beta2 counterpart of v23Configurator, for clusters
+// still expecting the deprecated v1beta2 API instead of v1beta3.
+type v23ConfiguratorV1beta2 struct {
+	config *schedulerv23v1beta2.KubeSchedulerConfiguration
+	codec  serializer.CodecFactory
+}
+
+var _ JSONConfigurator = &v23ConfiguratorV1beta2{}
+
+// DefaultConfigurationV1beta2 is the kubescheduler.config.k8s.io/v1beta2 counterpart of DefaultConfiguration.
+func DefaultConfigurationV1beta2(name, namespace string) *schedulerv23v1beta2.KubeSchedulerConfiguration {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(schedulerv23v1beta2.AddToScheme(scheme))
+
+	config := &schedulerv23v1beta2.KubeSchedulerConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: schedulerv23v1beta2.SchemeGroupVersion.String(),
+			Kind:       "KubeSchedulerConfiguration",
+		},
+	}
+	config.LeaderElection.ResourceName = name
+	config.LeaderElection.ResourceNamespace = namespace
+
+	scheme.Default(config)
+
+	return config
+}
+
+// NewConfiguratorV1beta2 is the kubescheduler.config.k8s.io/v1beta2 counterpart of NewConfigurator, for clusters
+// still expecting the deprecated v1beta2 API instead of v1beta3. It accepts the same ConfiguratorOptions.
+func NewConfiguratorV1beta2(resourceName, namespace string, config *schedulerv23v1beta2.KubeSchedulerConfiguration, opts ...ConfiguratorOption) (configurator.Configurator, error) {
+	options := &configuratorOptions{
+		leaseDuration: 15 * time.Second,
+		renewDeadline: 10 * time.Second,
+		retryPeriod:   2 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := validateUniqueSchedulerNamesV1beta2(config.Profiles); err != nil {
+		return nil, err
+	}
+
+	if err := validateSchedulingTuning(options.percentageOfNodesToScore, options.parallelism); err != nil {
+		return nil, err
+	}
+
+	scheme := runtime.NewScheme()
+
+	if err := schedulerv23v1beta2.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	leaderElection := componentbaseconfigv1alpha1.LeaderElectionConfiguration{
+		LeaseDuration: metav1.Duration{Duration: options.leaseDuration},
+		RenewDeadline: metav1.Duration{Duration: options.renewDeadline},
+		RetryPeriod:   metav1.Duration{Duration: options.retryPeriod},
+		ResourceLock:  "leases",
+		LeaderElect:   pointer.Bool(!options.disableLeaderElection),
+	}
+	if !options.disableLeaderElection {
+		leaderElection.ResourceName = resourceName
+		leaderElection.ResourceNamespace = namespace
+	}
+
+	config.LeaderElection = leaderElection
+
+	if options.clientConnectionQPS != nil {
+		config.ClientConnection.QPS = *options.clientConnectionQPS
+	}
+	if options.clientConnectionBurst != nil {
+		config.ClientConnection.Burst = *options.clientConnectionBurst
+	}
+	if options.clientConnectionContentType != nil {
+		config.ClientConnection.ContentType = *options.clientConnectionContentType
+	}
+	if options.clientConnectionAcceptContentTypes != nil {
+		config.ClientConnection.AcceptContentTypes = *options.clientConnectionAcceptContentTypes
+	}
+
+	if options.percentageOfNodesToScore != nil {
+		config.PercentageOfNodesToScore = options.percentageOfNodesToScore
+	}
+	if options.parallelism != nil {
+		config.Parallelism = options.parallelism
+	}
+
+	return &v23ConfiguratorV1beta2{
+		config: config,
+		codec:  serializer.NewCodecFactory(scheme, serializer.EnableStrict),
+	}, nil
+}
+
+// AppendDerivedProfileV1beta2 is the kubescheduler.config.k8s.io/v1beta2 counterpart of AppendDerivedProfile.
+func AppendDerivedProfileV1beta2(profiles []schedulerv23v1beta2.KubeSchedulerProfile, base schedulerv23v1beta2.KubeSchedulerProfile, schedulerName string) []schedulerv23v1beta2.KubeSchedulerProfile {
+	derived := *base.DeepCopy()
+	derived.SchedulerName = &schedulerName
+
+	return append(profiles, derived)
+}
+
+// validateUniqueSchedulerNamesV1beta2 is the kubescheduler.config.k8s.io/v1beta2 counterpart of
+// validateUniqueSchedulerNames.
+func validateUniqueSchedulerNamesV1beta2(profiles []schedulerv23v1beta2.KubeSchedulerProfile) error {
+	schedulerNames := sets.NewString()
+
+	for _, profile := range profiles {
+		if profile.SchedulerName == nil {
+			continue
+		}
+
+		if schedulerNames.Has(*profile.SchedulerName) {
+			return fmt.Errorf("duplicate scheduler name %q in profiles", *profile.SchedulerName)
+		}
+		schedulerNames.Insert(*profile.SchedulerName)
+	}
+
+	return nil
+}
+
+// MergePluginsIntoProfileV1beta2 is the kubescheduler.config.k8s.io/v1beta2 counterpart of MergePluginsIntoProfile.
+func MergePluginsIntoProfileV1beta2(profiles []schedulerv23v1beta2.KubeSchedulerProfile, schedulerName string, plugins *schedulerv23v1beta2.Plugins) []schedulerv23v1beta2.KubeSchedulerProfile {
+	if plugins == nil {
+		return profiles
+	}
+
+	for i, profile := range profiles {
+		if profile.SchedulerName == nil || *profile.SchedulerName != schedulerName {
+			continue
+		}
+
+		if profile.Plugins == nil {
+			profiles[i].Plugins = plugins
+			return profiles
+		}
+
+		profiles[i].Plugins = mergePluginsV1beta2(profile.Plugins, plugins)
+		return profiles
+	}
+
+	return append(profiles, schedulerv23v1beta2.KubeSchedulerProfile{
+		SchedulerName: &schedulerName,
+		Plugins:       plugins,
+	})
+}
+
+func mergePluginsV1beta2(existing, additional *schedulerv23v1beta2.Plugins) *schedulerv23v1beta2.Plugins {
+	merged := existing.DeepCopy()
+
+	for _, pair := range []struct {
+		dst *schedulerv23v1beta2.PluginSet
+		src schedulerv23v1beta2.PluginSet
+	}{
+		{&merged.QueueSort, additional.QueueSort},
+		{&merged.PreFilter, additional.PreFilter},
+		{&merged.Filter, additional.Filter},
+		{&merged.PostFilter, additional.PostFilter},
+		{&merged.PreScore, additional.PreScore},
+		{&merged.Score, additional.Score},
+		{&merged.Reserve, additional.Reserve},
+		{&merged.Permit, additional.Permit},
+		{&merged.PreBind, additional.PreBind},
+		{&merged.Bind, additional.Bind},
+		{&merged.PostBind, additional.PostBind},
+		{&merged.MultiPoint, additional.MultiPoint},
+	} {
+		if len(pair.dst.Enabled) == 0 && len(pair.dst.Disabled) == 0 {
+			*pair.dst = pair.src
+		}
+	}
+
+	return merged
+}
+
+func (c *v23ConfiguratorV1beta2) Config() (string, error) {
+	const mediaType = runtime.ContentTypeYAML
+
+	componentConfigYAML := &bytes.Buffer{}
+
+	info, ok := runtime.SerializerInfoForMediaType(c.codec.SupportedMediaTypes(), mediaType)
+	if !ok {
+		return "", fmt.Errorf("unable to locate encoder -- %q is not a supported media type", mediaType)
+	}
+
+	encoder := c.codec.EncoderForVersion(info.Serializer, schedulerv23v1beta2.SchemeGroupVersion)
+
+	if err := encoder.Encode(c.config, componentConfigYAML); err != nil {
+		return "", err
+	}
+
+	return componentConfigYAML.String(), nil
+}
+
+// ConfigJSON returns the same KubeSchedulerConfiguration as Config, but serialized as indented JSON instead of YAML.
+func (c *v23ConfiguratorV1beta2) ConfigJSON() (string, error) {
+	const mediaType = runtime.ContentTypeJSON
+
+	info, ok := runtime.SerializerInfoForMediaType(c.codec.SupportedMediaTypes(), mediaType)
+	if !ok {
+		return "", fmt.Errorf("unable to locate encoder -- %q is not a supported media type", mediaType)
+	}
+
+	encoder := c.codec.EncoderForVersion(info.Serializer, schedulerv23v1beta2.SchemeGroupVersion)
+
+	componentConfigJSON := &bytes.Buffer{}
+	if err := encoder.Encode(c.config, componentConfigJSON); err != nil {
+		return "", err
+	}
+
+	indentedComponentConfigJSON := &bytes.Buffer{}
+	if err := json.Indent(indentedComponentConfigJSON, componentConfigJSON.Bytes(), "", "  "); err != nil {
+		return "", err
+	}
+
+	return indentedComponentConfigJSON.String(), nil
+}
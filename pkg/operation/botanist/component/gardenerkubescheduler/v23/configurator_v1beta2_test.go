@@ -0,0 +1,157 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v23_test
+
+import (
+	"encoding/json"
+
+	v23 "github.com/gardener/gardener/pkg/operation/botanist/component/gardenerkubescheduler/v23"
+	"github.com/gardener/gardener/third_party/kube-scheduler/v23/v1beta2"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("NewConfiguratorV1beta2", func() {
+	It("should not return nil", func() {
+		c, err := v23.NewConfiguratorV1beta2("baz", "test", &v1beta2.KubeSchedulerConfiguration{})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+	})
+
+	It("should apply the percentageOfNodesToScore and parallelism overrides", func() {
+		config := &v1beta2.KubeSchedulerConfiguration{}
+		c, err := v23.NewConfiguratorV1beta2("baz", "test", config, v23.WithPercentageOfNodesToScore(30), v23.WithParallelism(8))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+		Expect(*config.PercentageOfNodesToScore).To(Equal(int32(30)))
+		Expect(*config.Parallelism).To(Equal(int32(8)))
+	})
+
+	It("should return an error if percentageOfNodesToScore is out of the 0-100 range", func() {
+		c, err := v23.NewConfiguratorV1beta2("baz", "test", &v1beta2.KubeSchedulerConfiguration{}, v23.WithPercentageOfNodesToScore(101))
+		Expect(err).To(MatchError(ContainSubstring("percentageOfNodesToScore must be between 0 and 100")))
+		Expect(c).To(BeNil())
+	})
+
+	It("should return an error if parallelism is not positive", func() {
+		c, err := v23.NewConfiguratorV1beta2("baz", "test", &v1beta2.KubeSchedulerConfiguration{}, v23.WithParallelism(0))
+		Expect(err).To(MatchError(ContainSubstring("parallelism must be greater than 0")))
+		Expect(c).To(BeNil())
+	})
+
+	It("should return an error if two profiles specify the same scheduler name", func() {
+		config := &v1beta2.KubeSchedulerConfiguration{
+			Profiles: []v1beta2.KubeSchedulerProfile{
+				{SchedulerName: pointer.String("default-scheduler")},
+				{SchedulerName: pointer.String("default-scheduler")},
+			},
+		}
+
+		c, err := v23.NewConfiguratorV1beta2("baz", "test", config)
+		Expect(err).To(MatchError(ContainSubstring(`duplicate scheduler name "default-scheduler"`)))
+		Expect(c).To(BeNil())
+	})
+})
+
+var _ = Describe("DefaultConfigurationV1beta2", func() {
+	It("should set the TypeMeta and produce a configuration that NewConfiguratorV1beta2 serializes identically to a plain configuration", func() {
+		defaultConfig := v23.DefaultConfigurationV1beta2("baz", "test")
+		Expect(defaultConfig.APIVersion).To(Equal("kubescheduler.config.k8s.io/v1beta2"))
+		Expect(defaultConfig.Kind).To(Equal("KubeSchedulerConfiguration"))
+
+		c, err := v23.NewConfiguratorV1beta2("baz", "test", defaultConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		defaultConfigYAML, err := c.Config()
+		Expect(err).NotTo(HaveOccurred())
+
+		plainConfig := &v1beta2.KubeSchedulerConfiguration{
+			TypeMeta: defaultConfig.TypeMeta,
+		}
+		plainC, err := v23.NewConfiguratorV1beta2("baz", "test", plainConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		plainConfigYAML, err := plainC.Config()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(defaultConfigYAML).To(Equal(plainConfigYAML))
+	})
+})
+
+var _ = Describe("ConfigV1beta2", func() {
+	It("returns correct config", func() {
+		c, err := v23.NewConfiguratorV1beta2("baz", "test", &v1beta2.KubeSchedulerConfiguration{
+			Profiles: []v1beta2.KubeSchedulerProfile{
+				{
+					SchedulerName: pointer.String("test"),
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+
+		output, err := c.Config()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(output).To(Equal(`apiVersion: kubescheduler.config.k8s.io/v1beta2
+clientConnection:
+  acceptContentTypes: ""
+  burst: 0
+  contentType: ""
+  kubeconfig: ""
+  qps: 0
+kind: KubeSchedulerConfiguration
+leaderElection:
+  leaderElect: true
+  leaseDuration: 15s
+  renewDeadline: 10s
+  resourceLock: leases
+  resourceName: baz
+  resourceNamespace: test
+  retryPeriod: 2s
+profiles:
+- schedulerName: test
+`))
+	})
+
+	It("returns the equivalent config as indented JSON", func() {
+		c, err := v23.NewConfiguratorV1beta2("baz", "test", &v1beta2.KubeSchedulerConfiguration{
+			Profiles: []v1beta2.KubeSchedulerProfile{
+				{
+					SchedulerName: pointer.String("test"),
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		jsonConfigurator, ok := c.(v23.JSONConfigurator)
+		Expect(ok).To(BeTrue())
+
+		configJSON, err := jsonConfigurator.ConfigJSON()
+		Expect(err).NotTo(HaveOccurred())
+
+		var roundTripped v1beta2.KubeSchedulerConfiguration
+		Expect(json.Unmarshal([]byte(configJSON), &roundTripped)).To(Succeed())
+		Expect(roundTripped.Profiles).To(Equal([]v1beta2.KubeSchedulerProfile{{SchedulerName: pointer.String("test")}}))
+		Expect(roundTripped.LeaderElection.ResourceName).To(Equal("baz"))
+		Expect(roundTripped.LeaderElection.ResourceNamespace).To(Equal("test"))
+
+		Expect(configJSON).To(ContainSubstring("\n  \""))
+	})
+})
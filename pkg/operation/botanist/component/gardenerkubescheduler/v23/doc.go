@@ -14,5 +14,7 @@
 
 // Package v23 contains a kube-scheduler specific configuration for
 // Kubernetes version 1.23. The used API version for the kube-scheduler's
-// component config is kubescheduler.config.k8s.io/v1beta3.
+// component config is kubescheduler.config.k8s.io/v1beta3. For clusters
+// still expecting the deprecated kubescheduler.config.k8s.io/v1beta2 API,
+// use the V1beta2-suffixed counterparts instead.
 package v23
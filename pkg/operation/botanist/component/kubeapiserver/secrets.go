@@ -26,9 +26,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/utils/pointer"
 )
@@ -195,39 +193,31 @@ func (k *kubeAPIServer) reconcileSecretUserKubeconfig(ctx context.Context, secre
 	return kutil.DeleteObject(ctx, k.client.Client(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "kubecfg", Namespace: k.namespace}})
 }
 
+// etcdEncryptionKeyAlgorithm is the etcd encryption provider used for the kube-apiserver's etcd encryption
+// configuration. It defaults to aescbc for backwards-compatibility with existing configurations.
+const etcdEncryptionKeyAlgorithm = secretutils.ETCDEncryptionKeyAlgorithmAESCBC
+
 func (k *kubeAPIServer) reconcileSecretETCDEncryptionConfiguration(ctx context.Context, secret *corev1.Secret) error {
 	keySecret, err := k.secretsManager.Generate(ctx, &secretutils.ETCDEncryptionKeySecretConfig{
-		Name:         secretETCDEncryptionKeyName,
-		SecretLength: 32,
+		Name:                secretETCDEncryptionKeyName,
+		SecretLength:        32,
+		EncryptionAlgorithm: etcdEncryptionKeyAlgorithm,
 	}, secretsmanager.Persist(), secretsmanager.Rotate(secretsmanager.KeepOld))
 	if err != nil {
 		return err
 	}
 
-	encryptionConfiguration := &apiserverconfigv1.EncryptionConfiguration{
-		Resources: []apiserverconfigv1.ResourceConfiguration{{
-			Resources: []string{
-				"secrets",
-			},
-			Providers: []apiserverconfigv1.ProviderConfiguration{
-				{
-					AESCBC: &apiserverconfigv1.AESConfiguration{
-						Keys: []apiserverconfigv1.Key{
-							{
-								Name:   string(keySecret.Data[secretutils.DataKeyEncryptionKeyName]),
-								Secret: string(keySecret.Data[secretutils.DataKeyEncryptionSecret]),
-							},
-						},
-					},
-				},
-				{
-					Identity: &apiserverconfigv1.IdentityConfiguration{},
-				},
-			},
-		}},
+	retainedKeys, err := secretutils.LoadRetainedEncryptionKeysFromCSV(keySecret.Data[secretutils.DataKeyRetainedEncryptionKeysCSV])
+	if err != nil {
+		return err
 	}
 
-	data, err := runtime.Encode(codec, encryptionConfiguration)
+	providerKeys := append([]secretutils.ETCDEncryptionKeyEntry{{
+		Key:    string(keySecret.Data[secretutils.DataKeyEncryptionKeyName]),
+		Secret: string(keySecret.Data[secretutils.DataKeyEncryptionSecret]),
+	}}, retainedKeys...)
+
+	data, err := secretutils.BuildEncryptionConfiguration(providerKeys, etcdEncryptionKeyAlgorithm)
 	if err != nil {
 		return err
 	}
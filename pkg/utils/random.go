@@ -16,6 +16,7 @@ package utils
 
 import (
 	cryptorand "crypto/rand"
+	"io"
 	"math/big"
 	mathrand "math/rand"
 	"time"
@@ -23,7 +24,21 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// GenerateRandomString uses crypto/rand to generate a random string of the specified length <n>.
+// RandSource is the source of cryptographically secure randomness used for key, string, and certificate generation
+// throughout this package and its callers. It defaults to crypto/rand.Reader, but can be overridden at startup
+// (e.g. with a FIPS-validated reader) to swap out the randomness source without having to touch any call sites.
+var RandSource io.Reader = defaultRandSource{}
+
+// defaultRandSource reads from crypto/rand.Reader on every call instead of copying it once, so that tests which
+// replace crypto/rand.Reader (e.g. via test.WithVar(&rand.Reader, ...)) keep working without having to know about
+// RandSource.
+type defaultRandSource struct{}
+
+func (defaultRandSource) Read(p []byte) (int, error) {
+	return cryptorand.Reader.Read(p)
+}
+
+// GenerateRandomString uses RandSource to generate a random string of the specified length <n>.
 // The set of allowed characters is [0-9a-zA-Z], thus no special characters are included in the output.
 // Returns error if there was a problem during the random generation.
 func GenerateRandomString(n int) (string, error) {
@@ -31,13 +46,14 @@ func GenerateRandomString(n int) (string, error) {
 	return GenerateRandomStringFromCharset(n, allowedCharacters)
 }
 
-// GenerateRandomStringFromCharset generates a cryptographically secure random string of the specified length <n>.
-// The set of allowed characters can be specified. Returns error if there was a problem during the random generation.
+// GenerateRandomStringFromCharset generates a cryptographically secure random string of the specified length <n>,
+// reading randomness from RandSource. The set of allowed characters can be specified. Returns error if there was a
+// problem during the random generation.
 func GenerateRandomStringFromCharset(n int, allowedCharacters string) (string, error) {
 	output := make([]byte, n)
 	max := new(big.Int).SetInt64(int64(len(allowedCharacters)))
 	for i := range output {
-		randomCharacter, err := cryptorand.Int(cryptorand.Reader, max)
+		randomCharacter, err := cryptorand.Int(RandSource, max)
 		if err != nil {
 			return "", err
 		}
@@ -46,6 +62,16 @@ func GenerateRandomStringFromCharset(n int, allowedCharacters string) (string, e
 	return string(output), nil
 }
 
+// GenerateRandomBytes uses RandSource to generate n cryptographically secure random bytes, e.g. for use as a raw
+// symmetric key. Returns an error if there was a problem during the random generation.
+func GenerateRandomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(RandSource, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
 // RandomDuration takes a time.Duration and computes a non-negative pseudo-random duration in [0,max).
 // It returns 0ns if max is <= 0ns.
 func RandomDuration(max time.Duration) time.Duration {
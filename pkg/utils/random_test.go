@@ -0,0 +1,67 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils_test
+
+import (
+	"bytes"
+	"io"
+
+	. "github.com/gardener/gardener/pkg/utils"
+	"github.com/gardener/gardener/pkg/utils/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("random", func() {
+	Describe("#GenerateRandomStringFromCharset", func() {
+		It("should read randomness from RandSource and produce reproducible output for a deterministic reader", func() {
+			DeferCleanup(test.WithVar(&RandSource, io.Reader(bytes.NewReader(bytes.Repeat([]byte{0}, 64)))))
+
+			result, err := GenerateRandomStringFromCharset(8, "abcdefgh")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal("aaaaaaaa"))
+		})
+
+		It("should produce the same output for two reads from equivalent deterministic readers", func() {
+			DeferCleanup(test.WithVar(&RandSource, io.Reader(bytes.NewReader(bytes.Repeat([]byte{1}, 64)))))
+			first, err := GenerateRandomStringFromCharset(8, "abcdefgh")
+			Expect(err).NotTo(HaveOccurred())
+
+			DeferCleanup(test.WithVar(&RandSource, io.Reader(bytes.NewReader(bytes.Repeat([]byte{1}, 64)))))
+			second, err := GenerateRandomStringFromCharset(8, "abcdefgh")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(first).To(Equal(second))
+		})
+	})
+
+	Describe("#GenerateRandomBytes", func() {
+		It("should read the requested number of bytes from RandSource", func() {
+			DeferCleanup(test.WithVar(&RandSource, io.Reader(bytes.NewReader(bytes.Repeat([]byte{2}, 64)))))
+
+			result, err := GenerateRandomBytes(8)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(bytes.Repeat([]byte{2}, 8)))
+		})
+
+		It("should return an error if RandSource does not yield enough bytes", func() {
+			DeferCleanup(test.WithVar(&RandSource, io.Reader(bytes.NewReader([]byte{1, 2, 3}))))
+
+			_, err := GenerateRandomBytes(8)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -15,6 +15,7 @@
 package secrets
 
 import (
+	"bytes"
 	"crypto/rsa"
 	"io"
 	"strings"
@@ -32,6 +33,13 @@ var (
 		return strings.Repeat("_", n), nil
 	}
 
+	// GenerateRandomBytes is an alias for utils.GenerateRandomBytes. Exposed for testing.
+	GenerateRandomBytes = utils.GenerateRandomBytes
+	// FakeGenerateRandomBytes is a fake for GenerateRandomBytes.
+	FakeGenerateRandomBytes = func(n int) ([]byte, error) {
+		return bytes.Repeat([]byte{0}, n), nil
+	}
+
 	// GenerateKey is an alias for rsa.GenerateKey. Exposed for testing.
 	GenerateKey = rsa.GenerateKey
 	// FakeGenerateKey is a fake for GenerateKey.
@@ -15,12 +15,16 @@
 package secrets
 
 import (
+	"bytes"
+	cryptorand "crypto/rand"
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/gardener/gardener/pkg/utils"
 	"github.com/gardener/gardener/pkg/utils/infodata"
 
+	"golang.org/x/crypto/bcrypt"
 	"k8s.io/apiserver/pkg/authentication/user"
 )
 
@@ -32,6 +36,13 @@ const (
 	BasicAuthFormatNormal formatType = "normal"
 	// BasicAuthFormatCSV indicates that the data map should be rendered in the CSV-format.
 	BasicAuthFormatCSV formatType = "csv"
+	// BasicAuthFormatBcrypt indicates that the data map should contain the bcrypt hash of the password in the 'auth'
+	// key (htpasswd-style, as expected by e.g. the nginx ingress controller), in addition to the dedicated username
+	// and (plaintext, for reference) password keys.
+	BasicAuthFormatBcrypt formatType = "bcrypt"
+
+	// bcryptDefaultCost is the default work factor used to hash passwords when BasicAuthFormatBcrypt is configured.
+	bcryptDefaultCost = bcrypt.DefaultCost
 
 	// DataKeyCSV is the key in a secret data holding the CSV format of a secret.
 	DataKeyCSV = "basic_auth.csv"
@@ -50,6 +61,49 @@ type BasicAuthSecretConfig struct {
 
 	Username       string
 	PasswordLength int
+
+	// PasswordComplexity optionally enforces that the generated password contains a minimum number of digits,
+	// uppercase, lowercase and special characters. If nil, the password is generated from the default alphanumeric
+	// character set without any composition guarantees.
+	PasswordComplexity *PasswordComplexity
+
+	// BcryptCost is the work factor used to hash the password when Format is BasicAuthFormatBcrypt. If zero, a sane
+	// default (bcrypt.DefaultCost) is used.
+	BcryptCost int
+
+	// OldPassword, if set, is included alongside the generated password in the 'auth'/CSV output, so that both
+	// credentials authenticate for the same username. This is populated automatically by the secrets manager when
+	// rotating with Rotate(KeepOld), to provide a grace period during which both passwords remain valid. It is
+	// excluded from the config checksum used for naming/change-detection, since it is derived, not configured.
+	OldPassword string `hash:"ignore"`
+}
+
+// PasswordComplexity specifies constraints on the character composition of a generated password.
+type PasswordComplexity struct {
+	// MinDigits is the minimum number of digits (0-9) the password must contain.
+	MinDigits int
+	// MinUpper is the minimum number of uppercase letters the password must contain.
+	MinUpper int
+	// MinLower is the minimum number of lowercase letters the password must contain.
+	MinLower int
+	// MinSpecial is the minimum number of special characters the password must contain.
+	MinSpecial int
+	// SpecialCharset is the set of characters used to satisfy MinSpecial. Defaults to specialCharset if empty.
+	SpecialCharset string
+}
+
+const (
+	digitCharset   = "0123456789"
+	upperCharset   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	lowerCharset   = "abcdefghijklmnopqrstuvwxyz"
+	specialCharset = "!@#$%^&*()-_=+"
+)
+
+func (c *PasswordComplexity) sumOfMinimums() int {
+	if c == nil {
+		return 0
+	}
+	return c.MinDigits + c.MinUpper + c.MinLower + c.MinSpecial
 }
 
 // BasicAuth contains the username, the password, optionally hash of the password and the format for serializing the basic authentication
@@ -59,6 +113,15 @@ type BasicAuth struct {
 
 	Username string
 	Password string
+
+	// OldPassword, if set, is rendered alongside Password so that both credentials authenticate for Username.
+	OldPassword string
+
+	// BcryptHash is the bcrypt hash of Password. It is only set when Format is BasicAuthFormatBcrypt.
+	BcryptHash string
+	// OldBcryptHash is the bcrypt hash of OldPassword. It is only set when Format is BasicAuthFormatBcrypt and
+	// OldPassword is set.
+	OldBcryptHash string
 }
 
 // GetName returns the name of the secret.
@@ -73,7 +136,7 @@ func (s *BasicAuthSecretConfig) Generate() (DataInterface, error) {
 
 // GenerateInfoData implements ConfigInterface.
 func (s *BasicAuthSecretConfig) GenerateInfoData() (infodata.InfoData, error) {
-	password, err := GenerateRandomString(s.PasswordLength)
+	password, err := s.generatePassword()
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +160,7 @@ func (s *BasicAuthSecretConfig) LoadFromSecretData(secretData map[string][]byte)
 	var password string
 
 	switch s.Format {
-	case BasicAuthFormatNormal:
+	case BasicAuthFormatNormal, BasicAuthFormatBcrypt:
 		password = string(secretData[DataKeyPassword])
 	case BasicAuthFormatCSV:
 		csv := strings.Split(string(secretData[DataKeyCSV]), ",")
@@ -113,7 +176,7 @@ func (s *BasicAuthSecretConfig) LoadFromSecretData(secretData map[string][]byte)
 // GenerateBasicAuth computes a username,password and the hash of the password keypair. It uses "admin" as username and generates a
 // random password of length 32.
 func (s *BasicAuthSecretConfig) GenerateBasicAuth() (*BasicAuth, error) {
-	password, err := GenerateRandomString(s.PasswordLength)
+	password, err := s.generatePassword()
 	if err != nil {
 		return nil, err
 	}
@@ -121,14 +184,106 @@ func (s *BasicAuthSecretConfig) GenerateBasicAuth() (*BasicAuth, error) {
 	return s.generateWithPassword(password)
 }
 
+// generatePassword generates a random password of the configured length. If PasswordComplexity is set, it guarantees
+// that the generated password contains at least the configured minimum number of digits, uppercase, lowercase and
+// special characters.
+func (s *BasicAuthSecretConfig) generatePassword() (string, error) {
+	if s.PasswordLength <= 0 {
+		return "", fmt.Errorf("password length must be a positive number, got %d", s.PasswordLength)
+	}
+
+	if s.PasswordComplexity == nil {
+		return GenerateRandomString(s.PasswordLength)
+	}
+
+	if minimum := s.PasswordComplexity.sumOfMinimums(); s.PasswordLength < minimum {
+		return "", fmt.Errorf("password length %d is smaller than the sum of the configured complexity minimums %d", s.PasswordLength, minimum)
+	}
+
+	special := s.PasswordComplexity.SpecialCharset
+	if special == "" {
+		special = specialCharset
+	}
+
+	var password []byte
+
+	for _, req := range []struct {
+		count   int
+		charset string
+	}{
+		{s.PasswordComplexity.MinDigits, digitCharset},
+		{s.PasswordComplexity.MinUpper, upperCharset},
+		{s.PasswordComplexity.MinLower, lowerCharset},
+		{s.PasswordComplexity.MinSpecial, special},
+	} {
+		if req.count == 0 {
+			continue
+		}
+		part, err := utils.GenerateRandomStringFromCharset(req.count, req.charset)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, []byte(part)...)
+	}
+
+	if remaining := s.PasswordLength - len(password); remaining > 0 {
+		fill, err := GenerateRandomString(remaining)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, []byte(fill)...)
+	}
+
+	if err := shuffleBytes(password); err != nil {
+		return "", err
+	}
+
+	return string(password), nil
+}
+
+// shuffleBytes randomizes the order of the given byte slice in-place using a cryptographically secure source of
+// randomness.
+func shuffleBytes(b []byte) error {
+	for i := len(b) - 1; i > 0; i-- {
+		j, err := cryptorand.Int(utils.RandSource, big.NewInt(int64(i+1)))
+		if err != nil {
+			return err
+		}
+		b[i], b[j.Int64()] = b[j.Int64()], b[i]
+	}
+	return nil
+}
+
 // generateWithPassword returns a BasicAuth secret DataInterface with the given password.
 func (s *BasicAuthSecretConfig) generateWithPassword(password string) (*BasicAuth, error) {
 	basicAuth := &BasicAuth{
 		Name:   s.Name,
 		Format: s.Format,
 
-		Username: s.Username,
-		Password: password,
+		Username:    s.Username,
+		Password:    password,
+		OldPassword: s.OldPassword,
+	}
+
+	if s.Format == BasicAuthFormatBcrypt {
+		cost := s.BcryptCost
+		if cost == 0 {
+			cost = bcryptDefaultCost
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+		if err != nil {
+			return nil, err
+		}
+		basicAuth.BcryptHash = string(hash)
+
+		if s.OldPassword != "" {
+			oldHash, err := bcrypt.GenerateFromPassword([]byte(s.OldPassword), cost)
+			if err != nil {
+				return nil, err
+			}
+			basicAuth.OldBcryptHash = string(oldHash)
+		}
 	}
 
 	return basicAuth, nil
@@ -142,17 +297,52 @@ func (b *BasicAuth) SecretData() map[string][]byte {
 	case BasicAuthFormatNormal:
 		data[DataKeyUserName] = []byte(b.Username)
 		data[DataKeyPassword] = []byte(b.Password)
-		data[DataKeySHA1Auth] = utils.CreateSHA1Secret(data[DataKeyUserName], data[DataKeyPassword])
+		data[DataKeySHA1Auth] = bytes.Join(b.sha1AuthLines(), []byte("\n"))
 
 		fallthrough
 
 	case BasicAuthFormatCSV:
-		data[DataKeyCSV] = []byte(fmt.Sprintf("%s,%s,%s,%s", b.Password, b.Username, b.Username, user.SystemPrivilegedGroup))
+		data[DataKeyCSV] = bytes.Join(b.csvLines(), []byte("\n"))
+
+	case BasicAuthFormatBcrypt:
+		data[DataKeyUserName] = []byte(b.Username)
+		data[DataKeyPassword] = []byte(b.Password)
+		data[DataKeySHA1Auth] = bytes.Join(b.bcryptAuthLines(), []byte("\n"))
 	}
 
 	return data
 }
 
+// sha1AuthLines returns the htpasswd-style (sha1-schemed) credentials line for Username/Password, plus an additional
+// line for OldPassword if set, so that both passwords authenticate.
+func (b *BasicAuth) sha1AuthLines() [][]byte {
+	lines := [][]byte{utils.CreateSHA1Secret([]byte(b.Username), []byte(b.Password))}
+	if b.OldPassword != "" {
+		lines = append(lines, utils.CreateSHA1Secret([]byte(b.Username), []byte(b.OldPassword)))
+	}
+	return lines
+}
+
+// bcryptAuthLines returns the htpasswd-style (bcrypt-schemed) credentials line for Username/BcryptHash, plus an
+// additional line for OldBcryptHash if set, so that both passwords authenticate.
+func (b *BasicAuth) bcryptAuthLines() [][]byte {
+	lines := [][]byte{[]byte(fmt.Sprintf("%s:%s", b.Username, b.BcryptHash))}
+	if b.OldBcryptHash != "" {
+		lines = append(lines, []byte(fmt.Sprintf("%s:%s", b.Username, b.OldBcryptHash)))
+	}
+	return lines
+}
+
+// csvLines returns the CSV-formatted credentials line for Username/Password, plus an additional line for
+// OldPassword if set, so that both passwords authenticate.
+func (b *BasicAuth) csvLines() [][]byte {
+	lines := [][]byte{[]byte(fmt.Sprintf("%s,%s,%s,%s", b.Password, b.Username, b.Username, user.SystemPrivilegedGroup))}
+	if b.OldPassword != "" {
+		lines = append(lines, []byte(fmt.Sprintf("%s,%s,%s,%s", b.OldPassword, b.Username, b.Username, user.SystemPrivilegedGroup)))
+	}
+	return lines
+}
+
 // LoadBasicAuthFromCSV loads the basic auth username and the password from the given CSV-formatted <data>.
 func LoadBasicAuthFromCSV(name string, data []byte) (*BasicAuth, error) {
 	csv := strings.Split(string(data), ",")
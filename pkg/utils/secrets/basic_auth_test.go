@@ -15,10 +15,12 @@
 package secrets_test
 
 import (
+	"github.com/gardener/gardener/pkg/utils"
 	. "github.com/gardener/gardener/pkg/utils/secrets"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/bcrypt"
 )
 
 var _ = Describe("Basic Auth Secrets", func() {
@@ -70,6 +72,101 @@ var _ = Describe("Basic Auth Secrets", func() {
 				Expect(err).NotTo(HaveOccurred())
 				compareCurrentAndExpectedBasicAuth(obj, expectedBasicAuthObject, false)
 			})
+
+			It("should return an error if PasswordLength is zero", func() {
+				basicAuthConfiguration.PasswordLength = 0
+				_, err := basicAuthConfiguration.Generate()
+				Expect(err).To(MatchError(ContainSubstring("password length must be a positive number")))
+			})
+
+			It("should return an error if PasswordLength is negative", func() {
+				basicAuthConfiguration.PasswordLength = -1
+				_, err := basicAuthConfiguration.Generate()
+				Expect(err).To(MatchError(ContainSubstring("password length must be a positive number")))
+			})
+		})
+
+		Describe("#Generate with PasswordComplexity", func() {
+			It("should generate many passwords fulfilling the complexity constraints", func() {
+				basicAuthConfiguration.PasswordLength = 20
+				basicAuthConfiguration.PasswordComplexity = &PasswordComplexity{
+					MinDigits:  3,
+					MinUpper:   2,
+					MinLower:   2,
+					MinSpecial: 1,
+				}
+
+				for i := 0; i < 100; i++ {
+					obj, err := basicAuthConfiguration.Generate()
+					Expect(err).NotTo(HaveOccurred())
+
+					basicAuth, ok := obj.(*BasicAuth)
+					Expect(ok).To(BeTrue())
+					Expect(basicAuth.Password).To(HaveLen(20))
+
+					var digits, upper, lower, special int
+					for _, r := range basicAuth.Password {
+						switch {
+						case r >= '0' && r <= '9':
+							digits++
+						case r >= 'A' && r <= 'Z':
+							upper++
+						case r >= 'a' && r <= 'z':
+							lower++
+						default:
+							special++
+						}
+					}
+
+					Expect(digits).To(BeNumerically(">=", 3))
+					Expect(upper).To(BeNumerically(">=", 2))
+					Expect(lower).To(BeNumerically(">=", 2))
+					Expect(special).To(BeNumerically(">=", 1))
+				}
+			})
+
+			It("should return an error if the password length is smaller than the sum of the minimums", func() {
+				basicAuthConfiguration.PasswordLength = 3
+				basicAuthConfiguration.PasswordComplexity = &PasswordComplexity{MinDigits: 2, MinUpper: 2}
+
+				_, err := basicAuthConfiguration.Generate()
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Describe("#Generate with BasicAuthFormatBcrypt", func() {
+			It("should generate a bcrypt hash of the password using the default cost", func() {
+				basicAuthConfiguration.Format = BasicAuthFormatBcrypt
+
+				obj, err := basicAuthConfiguration.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				basicAuth, ok := obj.(*BasicAuth)
+				Expect(ok).To(BeTrue())
+				Expect(basicAuth.Password).NotTo(BeEmpty())
+				Expect(basicAuth.BcryptHash).NotTo(BeEmpty())
+
+				Expect(bcrypt.CompareHashAndPassword([]byte(basicAuth.BcryptHash), []byte(basicAuth.Password))).To(Succeed())
+
+				cost, err := bcrypt.Cost([]byte(basicAuth.BcryptHash))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cost).To(Equal(bcrypt.DefaultCost))
+			})
+
+			It("should honor a custom bcrypt cost", func() {
+				basicAuthConfiguration.Format = BasicAuthFormatBcrypt
+				basicAuthConfiguration.BcryptCost = bcrypt.MinCost
+
+				obj, err := basicAuthConfiguration.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				basicAuth, ok := obj.(*BasicAuth)
+				Expect(ok).To(BeTrue())
+
+				cost, err := bcrypt.Cost([]byte(basicAuth.BcryptHash))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cost).To(Equal(bcrypt.MinCost))
+			})
 		})
 
 		Describe("#GenerateInfoData", func() {
@@ -156,6 +253,43 @@ var _ = Describe("Basic Auth Secrets", func() {
 				data := basicAuth.SecretData()
 				Expect(data).To(Equal(expectedCSVFormatData))
 			})
+			It("should properly return secret data if format is BasicAuthFormatBcrypt", func() {
+				hash, err := bcrypt.GenerateFromPassword([]byte("foo"), bcrypt.DefaultCost)
+				Expect(err).NotTo(HaveOccurred())
+
+				basicAuth.Format = BasicAuthFormatBcrypt
+				basicAuth.BcryptHash = string(hash)
+
+				data := basicAuth.SecretData()
+				Expect(data[DataKeyUserName]).To(Equal([]byte("admin")))
+				Expect(data[DataKeyPassword]).To(Equal([]byte("foo")))
+				Expect(data[DataKeySHA1Auth]).To(Equal([]byte("admin:" + string(hash))))
+				Expect(bcrypt.CompareHashAndPassword(hash, []byte("foo"))).To(Succeed())
+			})
+
+			It("should include both passwords if OldPassword is set and format is BasicAuthFormatNormal", func() {
+				basicAuth.Format = BasicAuthFormatNormal
+				basicAuth.OldPassword = "bar"
+
+				data := basicAuth.SecretData()
+				Expect(string(data[DataKeySHA1Auth])).To(Equal("admin:{SHA}C+7Hteo/D9vJXQ3UfzxbwnXaijM=\n" + string(utils.CreateSHA1Secret([]byte("admin"), []byte("bar")))))
+				Expect(string(data[DataKeyCSV])).To(Equal("foo,admin,admin,system:masters\nbar,admin,admin,system:masters"))
+			})
+
+			It("should include both passwords if OldPassword is set and format is BasicAuthFormatBcrypt", func() {
+				hash, err := bcrypt.GenerateFromPassword([]byte("foo"), bcrypt.DefaultCost)
+				Expect(err).NotTo(HaveOccurred())
+				oldHash, err := bcrypt.GenerateFromPassword([]byte("bar"), bcrypt.DefaultCost)
+				Expect(err).NotTo(HaveOccurred())
+
+				basicAuth.Format = BasicAuthFormatBcrypt
+				basicAuth.BcryptHash = string(hash)
+				basicAuth.OldPassword = "bar"
+				basicAuth.OldBcryptHash = string(oldHash)
+
+				data := basicAuth.SecretData()
+				Expect(string(data[DataKeySHA1Auth])).To(Equal("admin:" + string(hash) + "\nadmin:" + string(oldHash)))
+			})
 		})
 
 		Describe("#LoadBasicAuthFromCSV", func() {
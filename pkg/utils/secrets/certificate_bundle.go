@@ -23,16 +23,25 @@ import (
 // DataKeyCertificateBundle is the key in the data map for the certificate bundle.
 const DataKeyCertificateBundle = "bundle.crt"
 
+// DataKeySystemTrustStore is the key in the data map for the certificate bundle in the conventional file name
+// expected by components that mount their OS trust store (e.g. into an `/etc/ssl/certs`-style path).
+const DataKeySystemTrustStore = "ca-bundle.crt"
+
 // CertificateBundleSecretConfig is configuration for certificate bundles.
 type CertificateBundleSecretConfig struct {
 	Name            string
 	CertificatePEMs [][]byte
+
+	// IncludeSystemTrustStoreKey specifies whether the bundle should additionally be written under the
+	// DataKeySystemTrustStore data key, alongside DataKeyCertificateBundle, with identical content.
+	IncludeSystemTrustStoreKey bool
 }
 
 // CertificateBundle contains the name and the generated certificate bundle.
 type CertificateBundle struct {
-	Name   string
-	Bundle []byte
+	Name                       string
+	Bundle                     []byte
+	IncludeSystemTrustStoreKey bool
 }
 
 // GetName returns the name of the secret.
@@ -43,11 +52,15 @@ func (s *CertificateBundleSecretConfig) GetName() string {
 // Generate implements ConfigInterface.
 func (s *CertificateBundleSecretConfig) Generate() (DataInterface, error) {
 	return &CertificateBundle{
-		Name:   s.Name,
-		Bundle: s.generateBundle(),
+		Name:                       s.Name,
+		Bundle:                     s.generateBundle(),
+		IncludeSystemTrustStoreKey: s.IncludeSystemTrustStoreKey,
 	}, nil
 }
 
+// generateBundle concatenates CertificatePEMs in the given order. Callers are responsible for ordering
+// CertificatePEMs deterministically (e.g. newest-first by issuance) so that unchanged trust material yields
+// byte-identical bundles across repeated calls, avoiding needless secret updates.
 func (s *CertificateBundleSecretConfig) generateBundle() []byte {
 	var bundle []byte
 	for _, pem := range s.CertificatePEMs {
@@ -73,5 +86,9 @@ func (s *CertificateBundleSecretConfig) LoadFromSecretData(_ map[string][]byte)
 
 // SecretData computes the data map which can be used in a Kubernetes secret.
 func (v *CertificateBundle) SecretData() map[string][]byte {
-	return map[string][]byte{DataKeyCertificateBundle: v.Bundle}
+	data := map[string][]byte{DataKeyCertificateBundle: v.Bundle}
+	if v.IncludeSystemTrustStoreKey {
+		data[DataKeySystemTrustStore] = v.Bundle
+	}
+	return data
 }
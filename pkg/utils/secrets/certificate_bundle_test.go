@@ -55,6 +55,33 @@ var _ = Describe("CertificateBundle Secrets", func() {
 				Expect(bundle.Name).To(Equal(name))
 				Expect(bundle.Bundle).To(Equal(append(cert1, cert2...)))
 			})
+
+			It("should preserve the given block order and produce byte-identical output when generated again", func() {
+				obj1, err := config.Generate()
+				Expect(err).NotTo(HaveOccurred())
+				bundle1, ok := obj1.(*CertificateBundle)
+				Expect(ok).To(BeTrue())
+
+				obj2, err := config.Generate()
+				Expect(err).NotTo(HaveOccurred())
+				bundle2, ok := obj2.(*CertificateBundle)
+				Expect(ok).To(BeTrue())
+
+				Expect(bundle1.Bundle).To(Equal(bundle2.Bundle))
+				Expect(bundle1.Bundle).To(Equal(append(cert1, cert2...)))
+			})
+
+			It("should propagate IncludeSystemTrustStoreKey", func() {
+				config.IncludeSystemTrustStoreKey = true
+
+				obj, err := config.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				bundle, ok := obj.(*CertificateBundle)
+				Expect(ok).To(BeTrue())
+
+				Expect(bundle.IncludeSystemTrustStoreKey).To(BeTrue())
+			})
 		})
 	})
 
@@ -74,6 +101,15 @@ var _ = Describe("CertificateBundle Secrets", func() {
 					"bundle.crt": bundle.Bundle,
 				}))
 			})
+
+			It("should additionally include the system trust store key if IncludeSystemTrustStoreKey is set", func() {
+				bundle.IncludeSystemTrustStoreKey = true
+
+				Expect(bundle.SecretData()).To(Equal(map[string][]byte{
+					"bundle.crt":    bundle.Bundle,
+					"ca-bundle.crt": bundle.Bundle,
+				}))
+			})
 		})
 	})
 })
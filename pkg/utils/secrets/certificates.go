@@ -20,9 +20,11 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"fmt"
 	"math/big"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"sync"
@@ -68,26 +70,88 @@ const (
 	PKCS8
 )
 
+// encodeRSAPrivateKey PEM-encodes key according to pkcs, which must be PKCS1 or PKCS8. It is shared by
+// CertificateSecretConfig and RSASecretConfig so both honor the same encoding in the same way.
+func encodeRSAPrivateKey(pkcs int, key *rsa.PrivateKey) ([]byte, error) {
+	if pkcs == PKCS8 {
+		return utils.EncodePrivateKeyInPKCS8(key)
+	}
+	return utils.EncodePrivateKey(key), nil
+}
+
 // CertificateSecretConfig contains the specification a to-be-generated CA, server, or client certificate.
-// It always contains a 2048-bit RSA private key.
+// It always contains a 2048-bit RSA private key. Only RSA keys are supported: the private key type is fixed
+// throughout this package (GenerateKey, the Certificate.PrivateKey field, and the PEM encode/decode helpers in
+// package utils all hardcode *rsa.PrivateKey), so mixed-algorithm chains (e.g. an ECDSA or Ed25519 CA signing an
+// RSA leaf, or vice versa) cannot be expressed yet.
+//
+// NOTE: a request asking SignedByCA to pick its signature algorithm from the CA's key type, with an RSA/ECDSA/
+// Ed25519 CA x leaf test matrix, cannot be satisfied on top of this package as it stands: there is no ECDSA or
+// Ed25519 key support anywhere in this package to select an algorithm for in the first place (GenerateKey only
+// produces *rsa.PrivateKey, and Certificate.PrivateKey, encodeRSAPrivateKey, and utils.DecodePrivateKey are all
+// RSA-only). Adding it requires first generalizing PrivateKey (here and on Certificate) from *rsa.PrivateKey to
+// crypto.Signer across this package, its PEM encode/decode helpers in package utils, and every caller that type-
+// asserts or stores the concrete RSA type (e.g. pkg/utils/secrets/manager, kubeapiserver secret wiring) - a
+// cross-package refactor well beyond what a single change here can safely carry. Flagging this back rather than
+// bolting on a partial, untested algorithm-selection path: please file it as its own tracked change so the
+// crypto.Signer generalization can be reviewed and landed as one coherent unit.
 type CertificateSecretConfig struct {
 	Name string
 
 	CommonName   string
 	Organization []string
-	DNSNames     []string
-	IPAddresses  []net.IP
+	// OrganizationalUnit, Country, and Locality are additional X.509 Subject fields, e.g. for enterprise PKI policies
+	// requiring them on issued certificates. They are omitted from the Subject if left unset.
+	OrganizationalUnit []string
+	Country            []string
+	Locality           []string
+	DNSNames           []string
+	IPAddresses        []net.IP
+	// URIs is embedded into the certificate's URI SAN, e.g. to carry a SPIFFE SVID ('spiffe://...') for workload
+	// identity certificates. Every URI must be absolute.
+	URIs []*url.URL
 
 	CertType  CertType
 	SigningCA *Certificate
 	PKCS      int
 
+	// KeyUsages overrides the default key usages derived from CertType, if set. CA certificates always retain
+	// x509.KeyUsageCertSign and x509.KeyUsageCRLSign in addition to the configured usages, since a CA must be able
+	// to sign both certificates and CRLs.
+	KeyUsages []x509.KeyUsage
+	// ExtKeyUsages overrides the default extended key usages derived from CertType, if set.
+	ExtKeyUsages []x509.ExtKeyUsage
+
 	Validity                    *time.Duration
 	SkipPublishingCACertificate bool
 
+	// MustStaple specifies whether the OCSP must-staple TLS feature extension (RFC 7633) should be added to the
+	// certificate. It is only considered for server certificates.
+	MustStaple bool
+
+	// NotBeforeSkew backdates the certificate's 'NotBefore' field by the given duration relative to the current time.
+	// This is useful to tolerate clock skew on nodes whose clocks are slightly behind, which would otherwise reject
+	// a freshly issued certificate as not yet valid. It does not affect the certificate's 'NotAfter' field, i.e. its
+	// total validity period grows by NotBeforeSkew. Defaults to zero, which preserves the current behaviour.
+	NotBeforeSkew time.Duration
+
+	// TruncateCN specifies whether a CommonName exceeding maxCommonNameLength should be fit into the limit instead
+	// of failing validation, by deterministically replacing it with a truncated prefix plus a short hash of the full
+	// name. The full, untruncated name is additionally recorded in a URI SAN, recoverable via RecoverTruncatedCN.
+	TruncateCN bool
+
 	Clock clock.Clock
+
+	// SerialNumberFunc overrides how the certificate's serial number is produced, e.g. to draw it from a registry
+	// tracking previously issued serials instead of the default random generation. If nil, DefaultSerialNumberFunc
+	// is used.
+	SerialNumberFunc SerialNumberFunc `hash:"ignore"`
 }
 
+// SerialNumberFunc produces the serial number for a certificate about to be minted. Implementations must return a
+// positive, unique value.
+type SerialNumberFunc func() (*big.Int, error)
+
 // Certificate contains the private key, and the certificate. It does also contain the CA certificate
 // in case it is no CA. Otherwise, the <CA> field is nil.
 type Certificate struct {
@@ -108,6 +172,19 @@ func (s *CertificateSecretConfig) GetName() string {
 	return s.Name
 }
 
+// NewClientCertificateSecretConfig returns a CertificateSecretConfig for a client certificate signed by the given CA,
+// with its CommonName set to the given username and its Organization set to the given groups, as expected by
+// Kubernetes RBAC (see https://kubernetes.io/docs/reference/access-authn-authz/certificate-signing-requests/#normal-user).
+func NewClientCertificateSecretConfig(name, username string, groups []string, signingCA *Certificate) *CertificateSecretConfig {
+	return &CertificateSecretConfig{
+		Name:         name,
+		CommonName:   username,
+		Organization: groups,
+		CertType:     ClientCert,
+		SigningCA:    signingCA,
+	}
+}
+
 // Generate implements ConfigInterface.
 func (s *CertificateSecretConfig) Generate() (DataInterface, error) {
 	return s.GenerateCertificate()
@@ -177,8 +254,68 @@ func (s *CertificateSecretConfig) LoadFromSecretData(secretData map[string][]byt
 	return NewCertificateInfoData(privateKeyPEM, certificatePEM), nil
 }
 
+// maxCommonNameLength is the maximum length (in characters) an X.509 certificate's CommonName may have according to
+// RFC 5280 section 4.1.2.4 (the 'ub-common-name' upper bound).
+const maxCommonNameLength = 64
+
+// truncatedCNSANScheme is the URI scheme used to recover the full, untruncated common name from a certificate's SAN
+// once TruncateCN has truncated it to fit into maxCommonNameLength. See RecoverTruncatedCN.
+const truncatedCNSANScheme = "gardener-cn"
+
+// truncateCommonName deterministically fits cn into maxCommonNameLength characters by replacing everything beyond
+// the prefix with a short hash of the full name, so that two calls for the same cn always produce the same result.
+func truncateCommonName(cn string) string {
+	hash := utils.ComputeSHA256Hex([]byte(cn))[:8]
+	return cn[:maxCommonNameLength-len(hash)-1] + "-" + hash
+}
+
+// commonNameSAN returns a URI SAN which embeds the full, untruncated common name cn, so that it can be recovered
+// later via RecoverTruncatedCN even though the CommonName field itself only holds the truncated form.
+func commonNameSAN(cn string) *url.URL {
+	return &url.URL{Scheme: truncatedCNSANScheme, Opaque: url.QueryEscape(cn)}
+}
+
+// RecoverTruncatedCN returns the full, untruncated common name embedded by TruncateCN among the given URI SANs
+// (typically x509.Certificate.URIs), and true if one was found. It returns false if none of the given URIs were
+// produced by TruncateCN, e.g. because the option was not set when the certificate was generated.
+func RecoverTruncatedCN(uris []*url.URL) (string, bool) {
+	for _, uri := range uris {
+		if uri.Scheme != truncatedCNSANScheme {
+			continue
+		}
+		if cn, err := url.QueryUnescape(uri.Opaque); err == nil {
+			return cn, true
+		}
+	}
+	return "", false
+}
+
+// validate checks that the configuration will produce an RFC 5280 compliant certificate, returning a descriptive
+// error otherwise.
+func (s *CertificateSecretConfig) validate() error {
+	if len(s.CommonName) > maxCommonNameLength && !s.TruncateCN {
+		return fmt.Errorf("common name %q is longer than the %d characters allowed by RFC 5280 for certificate %q", s.CommonName, maxCommonNameLength, s.Name)
+	}
+
+	if s.CommonName == "" && s.CertType != "" && s.CertType != CACert {
+		return fmt.Errorf("common name must not be empty for %s certificate %q", s.CertType, s.Name)
+	}
+
+	for _, uri := range s.URIs {
+		if !uri.IsAbs() {
+			return fmt.Errorf("URI %q is not absolute for certificate %q", uri, s.Name)
+		}
+	}
+
+	return nil
+}
+
 // GenerateCertificate computes a CA, server, or client certificate based on the configuration.
 func (s *CertificateSecretConfig) GenerateCertificate() (*Certificate, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
 	certificateObj := &Certificate{
 		Name:                        s.Name,
 		CA:                          s.SigningCA,
@@ -187,13 +324,17 @@ func (s *CertificateSecretConfig) GenerateCertificate() (*Certificate, error) {
 
 	// If no cert type is given then we only return a certificate object that contains the CA.
 	if s.CertType != "" {
-		privateKey, err := GenerateKey(rand.Reader, 2048)
+		privateKey, err := GenerateKey(utils.RandSource, 2048)
+		if err != nil {
+			return nil, err
+		}
+
+		certificate, err := s.generateCertificateTemplate()
 		if err != nil {
 			return nil, err
 		}
 
 		var (
-			certificate       = s.generateCertificateTemplate()
 			certificateSigner = certificate
 			privateKeySigner  = privateKey
 		)
@@ -208,15 +349,9 @@ func (s *CertificateSecretConfig) GenerateCertificate() (*Certificate, error) {
 			return nil, err
 		}
 
-		var pk []byte
-		if s.PKCS == PKCS1 {
-			pk = utils.EncodePrivateKey(privateKey)
-		} else if s.PKCS == PKCS8 {
-			pk, err = utils.EncodePrivateKeyInPKCS8(privateKey)
-
-			if err != nil {
-				return nil, err
-			}
+		pk, err := encodeRSAPrivateKey(s.PKCS, privateKey)
+		if err != nil {
+			return nil, err
 		}
 
 		certificateObj.PrivateKey = privateKey
@@ -234,23 +369,40 @@ func (c *Certificate) SecretData() map[string][]byte {
 
 	switch {
 	case c.CA == nil:
-		// The certificate is a CA certificate itself, so we use different keys in the secret data (for backwards-
-		// compatibility).
+		// The certificate is a (root) CA certificate itself, so we use different keys in the secret data (for
+		// backwards-compatibility).
 		data[DataKeyCertificateCA] = c.CertificatePEM
 		data[DataKeyPrivateKeyCA] = c.PrivateKeyPEM
-	case c.CA != nil:
+	case c.Certificate != nil && c.Certificate.IsCA:
+		// The certificate is an intermediate CA certificate signed by another CA, so it is stored like a root CA
+		// (allowing it to be used as a signer itself), but the full chain up to the root is published.
+		data[DataKeyCertificateCA] = c.CertificateChainPEM()
+		data[DataKeyPrivateKeyCA] = c.PrivateKeyPEM
+	default:
 		// The certificate is not a CA certificate, so we add the signing CA certificate to it and use different
 		// keys in the secret data.
 		data[DataKeyPrivateKey] = c.PrivateKeyPEM
 		data[DataKeyCertificate] = c.CertificatePEM
 		if !c.SkipPublishingCACertificate {
-			data[DataKeyCertificateCA] = c.CA.CertificatePEM
+			data[DataKeyCertificateCA] = c.CA.CertificateChainPEM()
 		}
 	}
 
 	return data
 }
 
+// CertificateChainPEM returns the PEM-encoded certificate chain starting with this certificate, followed by its
+// signing CA (if any), its signing CA's signing CA, and so on up to the root CA. This allows an intermediate CA to
+// publish the full chain of trust instead of only its own certificate. The block order is deterministic (leaf first,
+// then intermediates, then the root), so an unchanged chain yields byte-identical output across repeated calls.
+func (c *Certificate) CertificateChainPEM() []byte {
+	chain := append([]byte{}, c.CertificatePEM...)
+	for ca := c.CA; ca != nil; ca = ca.CA {
+		chain = append(chain, ca.CertificatePEM...)
+	}
+	return chain
+}
+
 // LoadCertificate takes a byte slice representation of a certificate and the corresponding private key, and returns its de-serialized private
 // key, certificate template and PEM certificate which can be used to sign other x509 certificates.
 func LoadCertificate(name string, privateKeyPEM, certificatePEM []byte) (*Certificate, error) {
@@ -289,11 +441,52 @@ func LoadCAFromSecret(ctx context.Context, k8sClient client.Client, namespace, n
 	return secret, certificate, nil
 }
 
+// LoadCertificateFromSecret loads a Certificate from the raw <data> of a secret created by a CertificateSecretConfig
+// (or the secrets manager), looking up the well-known data keys itself so that callers don't need to parse
+// secret.Data[DataKeyCertificate] (or its CA counterpart) by hand. For CA secrets (only ca.crt/ca.key present) it
+// returns the CA's own certificate and private key. For leaf secrets (tls.crt/tls.key present) it returns the leaf
+// certificate and private key, with the CA field populated from the ca.crt entry if present - its private key is not
+// set since leaf secrets don't contain it.
+func LoadCertificateFromSecret(name string, data map[string][]byte) (*Certificate, error) {
+	if certificatePEM, privateKeyPEM := data[DataKeyCertificate], data[DataKeyPrivateKey]; len(certificatePEM) > 0 && len(privateKeyPEM) > 0 {
+		certificate, err := LoadCertificate(name, privateKeyPEM, certificatePEM)
+		if err != nil {
+			return nil, err
+		}
+
+		if caCertificatePEM := data[DataKeyCertificateCA]; len(caCertificatePEM) > 0 {
+			caCertificate, err := utils.DecodeCertificate(caCertificatePEM)
+			if err != nil {
+				return nil, err
+			}
+			certificate.CA = &Certificate{Name: name, Certificate: caCertificate, CertificatePEM: caCertificatePEM}
+		}
+
+		return certificate, nil
+	}
+
+	return LoadCertificate(name, data[DataKeyPrivateKeyCA], data[DataKeyCertificateCA])
+}
+
+// DefaultSerialNumberFunc is the default serial number provider used by generateCertificateTemplate if a
+// CertificateSecretConfig does not set SerialNumberFunc. It draws a random 128-bit serial number.
+var DefaultSerialNumberFunc SerialNumberFunc = func() (*big.Int, error) {
+	return rand.Int(utils.RandSource, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
 // generateCertificateTemplate creates a X509 Certificate object based on the provided information regarding
 // common name, organization, SANs (DNS names and IP addresses). It can create a server or a client certificate
 // or both, depending on the <certType> value. If <isCACert> is true, then a CA certificate is being created.
-// The certificates a valid for 10 years.
-func (s *CertificateSecretConfig) generateCertificateTemplate() *x509.Certificate {
+// The certificate (CA or leaf) is valid for 10 years unless Validity is set, in which case it is honored instead,
+// respecting Clock if set. A leaf certificate's 'NotAfter' is additionally capped at SigningCA's 'NotAfter', if set,
+// so that a leaf never outlives the CA that signs it.
+// DefaultSerialNumberFunc is the default serial number provider used by generateCertificateTemplate if a
+// CertificateSecretConfig does not set SerialNumberFunc. It draws a random 128-bit serial number.
+var DefaultSerialNumberFunc SerialNumberFunc = func() (*big.Int, error) {
+	return rand.Int(utils.RandSource, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+func (s *CertificateSecretConfig) generateCertificateTemplate() (*x509.Certificate, error) {
 	var clock clock.Clock = clock.RealClock{}
 
 	if s.Clock != nil {
@@ -306,23 +499,51 @@ func (s *CertificateSecretConfig) generateCertificateTemplate() *x509.Certificat
 		expiration = now.Add(*s.Validity)
 	}
 
+	if s.CertType != CACert && s.SigningCA != nil && s.SigningCA.Certificate.NotAfter.Before(expiration) {
+		expiration = s.SigningCA.Certificate.NotAfter
+	}
+
+	notBefore := now
+	if s.NotBeforeSkew > 0 {
+		notBefore = now.Add(-s.NotBeforeSkew)
+	}
+
+	commonName, uris := s.CommonName, s.URIs
+	if s.TruncateCN && len(commonName) > maxCommonNameLength {
+		commonName = truncateCommonName(s.CommonName)
+		uris = append(append([]*url.URL{}, s.URIs...), commonNameSAN(s.CommonName))
+	}
+
+	serialNumberFunc := DefaultSerialNumberFunc
+	if s.SerialNumberFunc != nil {
+		serialNumberFunc = s.SerialNumberFunc
+	}
+
+	serialNumber, err := serialNumberFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error generating serial number: %w", err)
+	}
+
 	var (
-		serialNumber, _ = rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
-		isCA            = s.CertType == CACert
+		isCA = s.CertType == CACert
 
 		template = &x509.Certificate{
 			BasicConstraintsValid: true,
 			IsCA:                  isCA,
 			SerialNumber:          serialNumber,
-			NotBefore:             now,
+			NotBefore:             notBefore,
 			NotAfter:              expiration,
 			KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
 			Subject: pkix.Name{
-				CommonName:   s.CommonName,
-				Organization: s.Organization,
+				CommonName:         commonName,
+				Organization:       s.Organization,
+				OrganizationalUnit: s.OrganizationalUnit,
+				Country:            s.Country,
+				Locality:           s.Locality,
 			},
 			DNSNames:    s.DNSNames,
 			IPAddresses: s.IPAddresses,
+			URIs:        uris,
 		}
 	)
 
@@ -337,14 +558,42 @@ func (s *CertificateSecretConfig) generateCertificateTemplate() *x509.Certificat
 		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
 	}
 
-	return template
+	if len(s.KeyUsages) > 0 {
+		template.KeyUsage = 0
+		for _, keyUsage := range s.KeyUsages {
+			template.KeyUsage |= keyUsage
+		}
+		if isCA {
+			template.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+		}
+	}
+
+	if len(s.ExtKeyUsages) > 0 {
+		template.ExtKeyUsage = s.ExtKeyUsages
+	}
+
+	if s.CertType == ServerCert && s.MustStaple {
+		template.ExtraExtensions = append(template.ExtraExtensions, pkix.Extension{
+			Id:    oidExtensionTLSFeature,
+			Value: mustStapleFeatureValue,
+		})
+	}
+
+	return template, nil
 }
 
+// oidExtensionTLSFeature is the OID for the "TLS Feature" (id-pe-tlsfeature) X.509 certificate extension (RFC 7633).
+var oidExtensionTLSFeature = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}
+
+// mustStapleFeatureValue is the DER encoding of a SEQUENCE OF INTEGER containing the value 5 (status_request),
+// signalling support for the OCSP must-staple TLS feature.
+var mustStapleFeatureValue = []byte{0x30, 0x03, 0x02, 0x01, 0x05}
+
 // SignCertificate takes a <certificateTemplate> and a <certificateTemplateSigner> which is used to sign
 // the first. It also requires the corresponding private keys of both certificates. The created certificate
 // is returned as byte slice.
 func signCertificate(certificateTemplate *x509.Certificate, privateKey *rsa.PrivateKey, certificateTemplateSigner *x509.Certificate, privateKeySigner *rsa.PrivateKey) ([]byte, error) {
-	certificate, err := x509.CreateCertificate(rand.Reader, certificateTemplate, certificateTemplateSigner, &privateKey.PublicKey, privateKeySigner)
+	certificate, err := x509.CreateCertificate(utils.RandSource, certificateTemplate, certificateTemplateSigner, &privateKey.PublicKey, privateKeySigner)
 	if err != nil {
 		return nil, err
 	}
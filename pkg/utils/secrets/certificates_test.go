@@ -15,11 +15,20 @@
 package secrets_test
 
 import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"net/url"
+	"strings"
+	"time"
+
 	"github.com/gardener/gardener/pkg/utils"
 	. "github.com/gardener/gardener/pkg/utils/secrets"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/clock"
 )
 
 var _ = Describe("Certificate Secrets", func() {
@@ -68,6 +77,23 @@ var _ = Describe("Certificate Secrets", func() {
 				Expect(certificate.Certificate).NotTo(BeNil())
 				Expect(certificate.CA).To(BeNil())
 			})
+
+			It("should PEM-encode the private key as PKCS8 when PKCS is set to PKCS8", func() {
+				certificateConfig.PKCS = PKCS8
+
+				obj, err := certificateConfig.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				certificate, ok := obj.(*Certificate)
+				Expect(ok).To(BeTrue())
+
+				block, _ := pem.Decode(certificate.PrivateKeyPEM)
+				Expect(block).NotTo(BeNil())
+
+				parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parsedKey).To(Equal(certificate.PrivateKey))
+			})
 		})
 
 		Describe("#GenerateInfoData", func() {
@@ -130,6 +156,539 @@ var _ = Describe("Certificate Secrets", func() {
 				Expect(certInfoData.PrivateKey).To(Equal(certificateInfoData.PrivateKey))
 			})
 		})
+
+		Describe("#LoadCertificateFromSecret", func() {
+			It("should load a CA certificate from secret data with CA keys", func() {
+				certificate, err := LoadCertificateFromSecret("ca", map[string][]byte{
+					DataKeyPrivateKeyCA:  privateKey,
+					DataKeyCertificateCA: cert,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(certificate.PrivateKeyPEM).To(Equal(privateKey))
+				Expect(certificate.CertificatePEM).To(Equal(cert))
+				Expect(certificate.Certificate).NotTo(BeNil())
+				Expect(certificate.PrivateKey).NotTo(BeNil())
+				Expect(certificate.CA).To(BeNil())
+			})
+
+			It("should load a leaf certificate with its CA from secret data with TLS cert keys", func() {
+				certificate, err := LoadCertificateFromSecret("server", map[string][]byte{
+					DataKeyPrivateKey:    privateKey,
+					DataKeyCertificate:   cert,
+					DataKeyCertificateCA: cert,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(certificate.PrivateKeyPEM).To(Equal(privateKey))
+				Expect(certificate.CertificatePEM).To(Equal(cert))
+				Expect(certificate.Certificate).NotTo(BeNil())
+				Expect(certificate.PrivateKey).NotTo(BeNil())
+				Expect(certificate.CA).NotTo(BeNil())
+				Expect(certificate.CA.CertificatePEM).To(Equal(cert))
+				Expect(certificate.CA.PrivateKey).To(BeNil())
+			})
+
+			It("should not populate the CA field when no CA certificate is present", func() {
+				certificate, err := LoadCertificateFromSecret("server", map[string][]byte{
+					DataKeyPrivateKey:  privateKey,
+					DataKeyCertificate: cert,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(certificate.CA).To(BeNil())
+			})
+		})
+
+		Describe("#GenerateCertificate with an intermediate CA chain", func() {
+			It("should sign a leaf certificate with an intermediate CA and publish the full chain", func() {
+				rootConfig := &CertificateSecretConfig{Name: "root", CommonName: "root", CertType: CACert}
+				root, err := rootConfig.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				intermediateConfig := &CertificateSecretConfig{
+					Name:       "intermediate",
+					CommonName: "intermediate",
+					CertType:   CACert,
+					SigningCA:  root,
+				}
+				intermediate, err := intermediateConfig.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				leafConfig := &CertificateSecretConfig{
+					Name:       "leaf",
+					CommonName: "leaf",
+					CertType:   ServerCert,
+					SigningCA:  intermediate,
+				}
+				leaf, err := leafConfig.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				intermediateData := intermediate.SecretData()
+				Expect(intermediateData[DataKeyCertificateCA]).To(Equal(append(append([]byte{}, intermediate.CertificatePEM...), root.CertificatePEM...)))
+				Expect(intermediateData[DataKeyPrivateKeyCA]).To(Equal(intermediate.PrivateKeyPEM))
+
+				leafData := leaf.SecretData()
+				Expect(leafData[DataKeyCertificateCA]).To(Equal(append(append([]byte{}, intermediate.CertificatePEM...), root.CertificatePEM...)))
+
+				parsedRoot, err := utils.DecodeCertificate(root.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+				parsedIntermediate, err := utils.DecodeCertificate(intermediate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+				parsedLeaf, err := utils.DecodeCertificate(leaf.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				pool := x509.NewCertPool()
+				pool.AddCert(parsedRoot)
+				intermediatePool := x509.NewCertPool()
+				intermediatePool.AddCert(parsedIntermediate)
+				_, err = parsedLeaf.Verify(x509.VerifyOptions{Roots: pool, Intermediates: intermediatePool})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("verifying the chain PEM block order is deterministic")
+				Expect(leaf.CA.CertificateChainPEM()).To(Equal(intermediate.CertificateChainPEM()))
+				Expect(leaf.CA.CertificateChainPEM()).To(Equal(append(append([]byte{}, intermediate.CertificatePEM...), root.CertificatePEM...)))
+			})
+		})
+
+		Describe("#GenerateCertificate with MustStaple", func() {
+			// id-pe-tlsfeature (RFC 7633), carrying the DER encoding of a SEQUENCE OF INTEGER with the value 5
+			// (status_request), i.e. OCSP must-staple.
+			oidExtensionTLSFeature := asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}
+			mustStapleFeatureValue := []byte{0x30, 0x03, 0x02, 0x01, 0x05}
+
+			It("should add the OCSP must-staple TLS feature extension to a server certificate", func() {
+				config := &CertificateSecretConfig{
+					Name:       "server",
+					CommonName: "server",
+					CertType:   ServerCert,
+					MustStaple: true,
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				parsedCertificate, err := utils.DecodeCertificate(certificate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(parsedCertificate.Extensions).To(ContainElement(pkix.Extension{
+					Id:    oidExtensionTLSFeature,
+					Value: mustStapleFeatureValue,
+				}))
+			})
+
+			It("should not add the extension if MustStaple is false", func() {
+				config := &CertificateSecretConfig{
+					Name:       "server",
+					CommonName: "server",
+					CertType:   ServerCert,
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				parsedCertificate, err := utils.DecodeCertificate(certificate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, ext := range parsedCertificate.Extensions {
+					Expect(ext.Id.Equal(oidExtensionTLSFeature)).To(BeFalse())
+				}
+			})
+
+			It("should not add the extension to non-server certificates", func() {
+				config := &CertificateSecretConfig{
+					Name:       "client",
+					CommonName: "client",
+					CertType:   ClientCert,
+					MustStaple: true,
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				parsedCertificate, err := utils.DecodeCertificate(certificate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, ext := range parsedCertificate.Extensions {
+					Expect(ext.Id.Equal(oidExtensionTLSFeature)).To(BeFalse())
+				}
+			})
+		})
+
+		Describe("#GenerateCertificate with CommonName validation", func() {
+			It("should fail when the CommonName exceeds 64 characters", func() {
+				config := &CertificateSecretConfig{
+					Name:       "server",
+					CommonName: strings.Repeat("a", 65),
+					CertType:   ServerCert,
+				}
+
+				_, err := config.GenerateCertificate()
+				Expect(err).To(MatchError(ContainSubstring("longer than the 64 characters")))
+			})
+
+			It("should fail when the CommonName is empty for a leaf certificate", func() {
+				config := &CertificateSecretConfig{
+					Name:     "server",
+					CertType: ServerCert,
+				}
+
+				_, err := config.GenerateCertificate()
+				Expect(err).To(MatchError(ContainSubstring("common name must not be empty")))
+			})
+
+			It("should succeed with an empty CommonName for a CA certificate", func() {
+				config := &CertificateSecretConfig{
+					Name:     "ca",
+					CertType: CACert,
+				}
+
+				_, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should succeed when a 64 character CommonName is used and the long name is carried via SANs instead", func() {
+				longDNSName := strings.Repeat("a", 65) + ".example.com"
+
+				config := &CertificateSecretConfig{
+					Name:       "server",
+					CommonName: strings.Repeat("a", 64),
+					CertType:   ServerCert,
+					DNSNames:   []string{longDNSName},
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				parsedCertificate, err := utils.DecodeCertificate(certificate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parsedCertificate.DNSNames).To(ContainElement(longDNSName))
+			})
+		})
+
+		Describe("#GenerateCertificate with TruncateCN", func() {
+			It("should truncate a CommonName exceeding 64 characters instead of failing", func() {
+				longCommonName := "system:node:" + strings.Repeat("a", 60)
+
+				config := &CertificateSecretConfig{
+					Name:       "server",
+					CommonName: longCommonName,
+					CertType:   ServerCert,
+					TruncateCN: true,
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(certificate.Certificate.Subject.CommonName)).To(Equal(64))
+				Expect(certificate.Certificate.Subject.CommonName).NotTo(Equal(longCommonName))
+			})
+
+			It("should leave a CommonName within the limit untouched", func() {
+				config := &CertificateSecretConfig{
+					Name:       "server",
+					CommonName: "server",
+					CertType:   ServerCert,
+					TruncateCN: true,
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(certificate.Certificate.Subject.CommonName).To(Equal("server"))
+				Expect(certificate.Certificate.URIs).To(BeEmpty())
+			})
+
+			It("should deterministically produce the same truncated CommonName for the same input", func() {
+				longCommonName := "system:node:" + strings.Repeat("a", 60)
+				config := &CertificateSecretConfig{
+					Name:       "server",
+					CommonName: longCommonName,
+					CertType:   ServerCert,
+					TruncateCN: true,
+				}
+
+				first, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+				second, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(first.Certificate.Subject.CommonName).To(Equal(second.Certificate.Subject.CommonName))
+			})
+
+			It("should carry the full CommonName in a SAN, recoverable via RecoverTruncatedCN", func() {
+				longCommonName := "system:node:" + strings.Repeat("a", 60)
+
+				config := &CertificateSecretConfig{
+					Name:       "server",
+					CommonName: longCommonName,
+					CertType:   ServerCert,
+					TruncateCN: true,
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				parsedCertificate, err := utils.DecodeCertificate(certificate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				recovered, ok := RecoverTruncatedCN(parsedCertificate.URIs)
+				Expect(ok).To(BeTrue())
+				Expect(recovered).To(Equal(longCommonName))
+			})
+
+			It("should report no recoverable CommonName when TruncateCN was not used", func() {
+				_, ok := RecoverTruncatedCN(nil)
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		Describe("#GenerateCertificate with URI SANs", func() {
+			It("should embed a SPIFFE URI SAN which is present and parseable on the resulting certificate", func() {
+				spiffeID, err := url.Parse("spiffe://example.org/ns/default/sa/my-workload")
+				Expect(err).NotTo(HaveOccurred())
+
+				config := &CertificateSecretConfig{
+					Name:       "server",
+					CommonName: "my-workload",
+					CertType:   ServerCert,
+					URIs:       []*url.URL{spiffeID},
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				parsedCertificate, err := utils.DecodeCertificate(certificate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parsedCertificate.URIs).To(ConsistOf(spiffeID))
+			})
+
+			It("should fail when a URI is not absolute", func() {
+				relativeURI, err := url.Parse("/ns/default/sa/my-workload")
+				Expect(err).NotTo(HaveOccurred())
+
+				config := &CertificateSecretConfig{
+					Name:       "server",
+					CommonName: "my-workload",
+					CertType:   ServerCert,
+					URIs:       []*url.URL{relativeURI},
+				}
+
+				_, err = config.GenerateCertificate()
+				Expect(err).To(MatchError(ContainSubstring("is not absolute")))
+			})
+		})
+
+		Describe("#GenerateCertificate with NotBeforeSkew", func() {
+			It("should backdate NotBefore by the configured skew without affecting NotAfter", func() {
+				fakeClock := clock.NewFakeClock(time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC))
+				skew := 5 * time.Minute
+
+				config := &CertificateSecretConfig{
+					Name:       "server",
+					CommonName: "server",
+					CertType:   ServerCert,
+					Clock:      fakeClock,
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+				parsedCertificate, err := utils.DecodeCertificate(certificate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				configWithSkew := &CertificateSecretConfig{
+					Name:          "server",
+					CommonName:    "server",
+					CertType:      ServerCert,
+					Clock:         fakeClock,
+					NotBeforeSkew: skew,
+				}
+
+				certificateWithSkew, err := configWithSkew.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+				parsedCertificateWithSkew, err := utils.DecodeCertificate(certificateWithSkew.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(parsedCertificateWithSkew.NotBefore).To(Equal(fakeClock.Now().Add(-skew)))
+				Expect(parsedCertificateWithSkew.NotAfter).To(Equal(parsedCertificate.NotAfter))
+			})
+
+			It("should default to NotBefore equal to now when NotBeforeSkew is zero", func() {
+				fakeClock := clock.NewFakeClock(time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC))
+
+				config := &CertificateSecretConfig{
+					Name:       "server",
+					CommonName: "server",
+					CertType:   ServerCert,
+					Clock:      fakeClock,
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+				parsedCertificate, err := utils.DecodeCertificate(certificate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(parsedCertificate.NotBefore).To(Equal(fakeClock.Now()))
+			})
+		})
+
+		Describe("#GenerateCertificate with custom validity", func() {
+			It("should honor a configured validity for CA certificates", func() {
+				fakeClock := clock.NewFakeClock(time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC))
+				validity := 2 * 365 * 24 * time.Hour
+
+				config := &CertificateSecretConfig{
+					Name:       "ca",
+					CommonName: "ca",
+					CertType:   CACert,
+					Clock:      fakeClock,
+					Validity:   &validity,
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+				parsedCertificate, err := utils.DecodeCertificate(certificate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(parsedCertificate.NotAfter).To(Equal(fakeClock.Now().Add(validity)))
+			})
+
+			It("should default CA certificates to 10 years validity when unset", func() {
+				fakeClock := clock.NewFakeClock(time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC))
+
+				config := &CertificateSecretConfig{
+					Name:       "ca",
+					CommonName: "ca",
+					CertType:   CACert,
+					Clock:      fakeClock,
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+				parsedCertificate, err := utils.DecodeCertificate(certificate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(parsedCertificate.NotAfter).To(Equal(fakeClock.Now().AddDate(10, 0, 0)))
+			})
+		})
+
+		Describe("#GenerateCertificate with custom key usages", func() {
+			It("should generate a dual-purpose certificate with both server and client auth EKUs", func() {
+				config := &CertificateSecretConfig{
+					Name:         "dual-purpose",
+					CommonName:   "dual-purpose",
+					CertType:     ServerCert,
+					KeyUsages:    []x509.KeyUsage{x509.KeyUsageDigitalSignature, x509.KeyUsageKeyEncipherment},
+					ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageCodeSigning},
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				parsedCertificate, err := utils.DecodeCertificate(certificate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(parsedCertificate.KeyUsage).To(Equal(x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment))
+				Expect(parsedCertificate.ExtKeyUsage).To(ConsistOf(x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageCodeSigning))
+			})
+
+			It("should always retain CertSign and CRLSign for CA certificates even with custom key usages", func() {
+				config := &CertificateSecretConfig{
+					Name:       "ca-custom",
+					CommonName: "ca-custom",
+					CertType:   CACert,
+					KeyUsages:  []x509.KeyUsage{x509.KeyUsageDigitalSignature},
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				parsedCertificate, err := utils.DecodeCertificate(certificate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(parsedCertificate.KeyUsage & x509.KeyUsageCertSign).To(Equal(x509.KeyUsageCertSign))
+				Expect(parsedCertificate.KeyUsage & x509.KeyUsageCRLSign).To(Equal(x509.KeyUsageCRLSign))
+			})
+		})
+
+		Describe("#GenerateCertificate with custom subject fields", func() {
+			It("should set OrganizationalUnit, Country, and Locality on the certificate subject", func() {
+				config := &CertificateSecretConfig{
+					Name:               "enterprise",
+					CommonName:         "enterprise",
+					CertType:           ServerCert,
+					DNSNames:           []string{"enterprise.example.com"},
+					Organization:       []string{"example Inc."},
+					OrganizationalUnit: []string{"IT", "Security"},
+					Country:            []string{"DE"},
+					Locality:           []string{"Walldorf"},
+				}
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				parsedCertificate, err := utils.DecodeCertificate(certificate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(parsedCertificate.Subject.Organization).To(ConsistOf("example Inc."))
+				Expect(parsedCertificate.Subject.OrganizationalUnit).To(ConsistOf("IT", "Security"))
+				Expect(parsedCertificate.Subject.Country).To(ConsistOf("DE"))
+				Expect(parsedCertificate.Subject.Locality).To(ConsistOf("Walldorf"))
+			})
+		})
+
+		Describe("#GenerateCertificate with a leaf validity exceeding the CA's", func() {
+			It("should clamp the leaf's NotAfter to the CA's NotAfter", func() {
+				caValidity := time.Hour
+				caConfig := &CertificateSecretConfig{
+					Name:       "ca",
+					CommonName: "ca",
+					CertType:   CACert,
+					Validity:   &caValidity,
+				}
+				ca, err := caConfig.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				parsedCA, err := utils.DecodeCertificate(ca.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				leafValidity := 24 * time.Hour
+				leafConfig := &CertificateSecretConfig{
+					Name:       "leaf",
+					CommonName: "leaf",
+					CertType:   ServerCert,
+					DNSNames:   []string{"leaf.example.com"},
+					SigningCA:  ca,
+					Validity:   &leafValidity,
+				}
+				leaf, err := leafConfig.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				parsedLeaf, err := utils.DecodeCertificate(leaf.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(parsedLeaf.NotAfter).To(Equal(parsedCA.NotAfter))
+			})
+		})
+
+		Describe("#NewClientCertificateSecretConfig", func() {
+			It("should set the CommonName to the username and Organization to the groups", func() {
+				caConfig := &CertificateSecretConfig{Name: "ca", CommonName: "ca", CertType: CACert}
+				ca, err := caConfig.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				config := NewClientCertificateSecretConfig("client", "jane.doe", []string{"system:masters", "developers"}, ca)
+				Expect(config.CertType).To(Equal(ClientCert))
+				Expect(config.SigningCA).To(Equal(ca))
+
+				certificate, err := config.GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				parsedCertificate, err := utils.DecodeCertificate(certificate.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(parsedCertificate.Subject.CommonName).To(Equal("jane.doe"))
+				Expect(parsedCertificate.Subject.Organization).To(ConsistOf("system:masters", "developers"))
+			})
+		})
 	})
 
 	Describe("Certificate Object", func() {
@@ -161,6 +720,16 @@ var _ = Describe("Certificate Secrets", func() {
 				}))
 			})
 
+			It("should publish the full chain if certificate is an intermediate CA", func() {
+				certificate.Certificate = &x509.Certificate{IsCA: true}
+				certificate.CA = &Certificate{CertificatePEM: []byte("ca")}
+
+				Expect(certificate.SecretData()).To(Equal(map[string][]byte{
+					DataKeyPrivateKeyCA:  []byte("foo"),
+					DataKeyCertificateCA: []byte("barca"),
+				}))
+			})
+
 			It("should properly return secret data if certificate type is server, client or both w/o publishing CA", func() {
 				certificate.CA = &Certificate{CertificatePEM: []byte("ca")}
 				certificate.SkipPublishingCACertificate = true
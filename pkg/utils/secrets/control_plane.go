@@ -43,6 +43,13 @@ type ControlPlaneSecretConfig struct {
 	Token     *Token
 
 	KubeConfigRequests []KubeConfigRequest
+
+	// CABundlePEM overrides the CA certificate embedded in a generated kubeconfig's certificate-authority-data for
+	// server verification, e.g. with the full CA bundle (current and, if present, old CA certificate) so that the
+	// kubeconfig keeps working against an API server presenting a certificate from either CA during a CA rotation.
+	// It only applies to KubeConfigRequests which don't already set their own CAData. If unset, each request falls
+	// back to its own CAData, and finally to the signing certificate's CA.
+	CABundlePEM []byte
 }
 
 // KubeConfigRequest is a struct which holds information about a Kubeconfig to be generated.
@@ -74,6 +81,10 @@ func (s *ControlPlaneSecretConfig) Generate() (DataInterface, error) {
 
 // GenerateInfoData implements ConfigInterface
 func (s *ControlPlaneSecretConfig) GenerateInfoData() (infodata.InfoData, error) {
+	if s.CertificateSecretConfig == nil {
+		return infodata.EmptyInfoData, nil
+	}
+
 	s.CertificateSecretConfig.Name = s.Name
 
 	cert, err := s.CertificateSecretConfig.GenerateCertificate()
@@ -90,17 +101,21 @@ func (s *ControlPlaneSecretConfig) GenerateInfoData() (infodata.InfoData, error)
 
 // GenerateFromInfoData implements ConfigInterface
 func (s *ControlPlaneSecretConfig) GenerateFromInfoData(infoData infodata.InfoData) (DataInterface, error) {
-	data, ok := infoData.(*CertificateInfoData)
-	if !ok {
-		return nil, fmt.Errorf("could not convert InfoData entry %s to CertificateInfoData", s.Name)
-	}
+	var certificate *Certificate
 
-	certificate := &Certificate{
-		Name: s.Name,
-		CA:   s.CertificateSecretConfig.SigningCA,
+	if s.CertificateSecretConfig != nil {
+		data, ok := infoData.(*CertificateInfoData)
+		if !ok {
+			return nil, fmt.Errorf("could not convert InfoData entry %s to CertificateInfoData", s.Name)
+		}
+
+		certificate = &Certificate{
+			Name: s.Name,
+			CA:   s.CertificateSecretConfig.SigningCA,
 
-		PrivateKeyPEM:  data.PrivateKey,
-		CertificatePEM: data.Certificate,
+			PrivateKeyPEM:  data.PrivateKey,
+			CertificatePEM: data.Certificate,
+		}
 	}
 
 	controlPlane := &ControlPlane{
@@ -263,6 +278,9 @@ func GenerateKubeconfig(secret *ControlPlaneSecretConfig, certificate *Certifica
 
 	for _, req := range secret.KubeConfigRequests {
 		caData := req.CAData
+		if caData == nil {
+			caData = secret.CABundlePEM
+		}
 		if caData == nil && certificate != nil && certificate.CA != nil {
 			caData = certificate.CA.CertificatePEM
 		}
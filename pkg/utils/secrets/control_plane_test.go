@@ -15,7 +15,9 @@
 package secrets_test
 
 import (
+	"github.com/gardener/gardener/pkg/utils/infodata"
 	. "github.com/gardener/gardener/pkg/utils/secrets"
+
 	"github.com/ghodss/yaml"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -259,6 +261,63 @@ var _ = Describe("utils", func() {
 					Expect(kubecfg.AuthInfos[0].AuthInfo.Password).To(Equal(basicAuthPass))
 				})
 			})
+
+			Context("with a token instead of a client certificate", func() {
+				It("should return a kubeconfig embedding the token and no client-certificate fields", func() {
+					secret.BasicAuth = nil
+					secret.Token = &Token{Token: "my-token"}
+					secret.KubeConfigRequests[0].CAData = []byte(caCert)
+
+					kubeconfig, err := GenerateKubeconfig(secret, nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = yaml.Unmarshal(kubeconfig, &kubecfg)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(kubecfg.CurrentContext).To(Equal(clusterName))
+					Expect(kubecfg.Clusters).To(HaveLen(1))
+					Expect(kubecfg.Contexts).To(HaveLen(1))
+					Expect(kubecfg.AuthInfos).To(HaveLen(1))
+					Expect(kubecfg.Clusters[0].Cluster.Server).To(Equal("https://" + apiServerURL))
+					Expect(kubecfg.Clusters[0].Cluster.CertificateAuthorityData).To(Equal([]byte(caCert)))
+					Expect(kubecfg.Contexts[0].Context.AuthInfo).To(Equal(kubecfg.AuthInfos[0].Name))
+					Expect(kubecfg.AuthInfos[0].AuthInfo.Token).To(Equal("my-token"))
+					Expect(kubecfg.AuthInfos[0].AuthInfo.ClientCertificateData).To(BeEmpty())
+					Expect(kubecfg.AuthInfos[0].AuthInfo.ClientKeyData).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("#GenerateFromInfoData", func() {
+		It("should generate a token-based ControlPlane without a certificate when CertificateSecretConfig is nil", func() {
+			secret := &ControlPlaneSecretConfig{
+				Name:  "kube-proxy",
+				Token: &Token{Token: "my-token"},
+				KubeConfigRequests: []KubeConfigRequest{{
+					ClusterName:   "test-cluster",
+					APIServerHost: "kube-apiserver",
+					CAData:        []byte("ca-data"),
+				}},
+			}
+
+			infoData, err := secret.GenerateInfoData()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(infoData).To(Equal(infodata.EmptyInfoData))
+
+			dataInterface, err := secret.GenerateFromInfoData(infoData)
+			Expect(err).NotTo(HaveOccurred())
+
+			controlPlane, ok := dataInterface.(*ControlPlane)
+			Expect(ok).To(BeTrue())
+			Expect(controlPlane.Certificate).To(BeNil())
+			Expect(controlPlane.Kubeconfig).NotTo(BeEmpty())
+
+			var kubecfg clientcmdv1.Config
+			Expect(yaml.Unmarshal(controlPlane.Kubeconfig, &kubecfg)).To(Succeed())
+			Expect(kubecfg.AuthInfos).To(HaveLen(1))
+			Expect(kubecfg.AuthInfos[0].AuthInfo.Token).To(Equal("my-token"))
+			Expect(kubecfg.AuthInfos[0].AuthInfo.ClientCertificateData).To(BeEmpty())
 		})
 	})
 })
@@ -0,0 +1,110 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gardener/gardener/pkg/utils/infodata"
+)
+
+// DataKeyDockerConfigJSON is the key in a secret data holding the '.dockerconfigjson' payload, mirroring
+// corev1.DockerConfigJsonKey.
+const DataKeyDockerConfigJSON = ".dockerconfigjson"
+
+// DockerConfigJSONSecretConfig is configuration for assembling a '.dockerconfigjson' payload (as consumed by
+// kubernetes.io/dockerconfigjson secrets) from a set of registry credentials.
+type DockerConfigJSONSecretConfig struct {
+	Name string
+
+	// Server is the registry host (and optional port) the credentials apply to, e.g. 'registry.example.com'.
+	Server string
+	// Username is the registry username.
+	Username string
+	// Password is the registry password.
+	Password string
+	// Email is the (optional) e-mail address associated with the registry account.
+	Email string
+}
+
+// dockerConfigJSON mirrors the shape of the '.dockerconfigjson' payload consumed by the kubelet's image pull
+// credential provider (see https://kubernetes.io/docs/tasks/configure-pod-container/pull-image-private-registry/).
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email,omitempty"`
+	Auth     string `json:"auth"`
+}
+
+// DockerConfigJSON contains the name and the generated '.dockerconfigjson' payload.
+type DockerConfigJSON struct {
+	Name string
+	JSON []byte
+}
+
+// GetName returns the name of the secret.
+func (s *DockerConfigJSONSecretConfig) GetName() string {
+	return s.Name
+}
+
+// Generate implements ConfigInterface.
+func (s *DockerConfigJSONSecretConfig) Generate() (DataInterface, error) {
+	if s.Server == "" || s.Username == "" || s.Password == "" {
+		return nil, fmt.Errorf("server, username and password must be set to generate a docker config json for %q", s.Name)
+	}
+
+	payload, err := json.Marshal(dockerConfigJSON{
+		Auths: map[string]dockerConfigEntry{
+			s.Server: {
+				Username: s.Username,
+				Password: s.Password,
+				Email:    s.Email,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(s.Username + ":" + s.Password)),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DockerConfigJSON{Name: s.Name, JSON: payload}, nil
+}
+
+// GenerateInfoData implements ConfigInterface.
+func (s *DockerConfigJSONSecretConfig) GenerateInfoData() (infodata.InfoData, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GenerateFromInfoData implements ConfigInterface.
+func (s *DockerConfigJSONSecretConfig) GenerateFromInfoData(_ infodata.InfoData) (DataInterface, error) {
+	return nil, errors.New("not implemented")
+}
+
+// LoadFromSecretData implements infodata.Loader.
+func (s *DockerConfigJSONSecretConfig) LoadFromSecretData(_ map[string][]byte) (infodata.InfoData, error) {
+	return nil, errors.New("not implemented")
+}
+
+// SecretData computes the data map which can be used in a Kubernetes secret.
+func (d *DockerConfigJSON) SecretData() map[string][]byte {
+	return map[string][]byte{DataKeyDockerConfigJSON: d.JSON}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	. "github.com/gardener/gardener/pkg/utils/secrets"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Docker Config JSON Secrets", func() {
+	Describe("Docker Config JSON Secret Configuration", func() {
+		var config *DockerConfigJSONSecretConfig
+
+		BeforeEach(func() {
+			config = &DockerConfigJSONSecretConfig{
+				Name:     "docker-config",
+				Server:   "registry.example.com",
+				Username: "user",
+				Password: "pass",
+				Email:    "user@example.com",
+			}
+		})
+
+		Describe("#Generate", func() {
+			It("should assemble a valid dockerconfigjson payload", func() {
+				obj, err := config.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				dockerConfigJSON, ok := obj.(*DockerConfigJSON)
+				Expect(ok).To(BeTrue())
+
+				payload := struct {
+					Auths map[string]struct {
+						Username string `json:"username"`
+						Password string `json:"password"`
+						Email    string `json:"email"`
+						Auth     string `json:"auth"`
+					} `json:"auths"`
+				}{}
+				Expect(json.Unmarshal(dockerConfigJSON.JSON, &payload)).To(Succeed())
+
+				Expect(payload.Auths).To(HaveKey("registry.example.com"))
+				entry := payload.Auths["registry.example.com"]
+				Expect(entry.Username).To(Equal("user"))
+				Expect(entry.Password).To(Equal("pass"))
+				Expect(entry.Email).To(Equal("user@example.com"))
+				Expect(entry.Auth).To(Equal(base64.StdEncoding.EncodeToString([]byte("user:pass"))))
+			})
+
+			It("should omit the email field when not set", func() {
+				config.Email = ""
+
+				obj, err := config.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				dockerConfigJSON, ok := obj.(*DockerConfigJSON)
+				Expect(ok).To(BeTrue())
+				Expect(dockerConfigJSON.JSON).NotTo(ContainSubstring("email"))
+			})
+
+			It("should return an error if the server is not set", func() {
+				config.Server = ""
+				_, err := config.Generate()
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("should return an error if the username is not set", func() {
+				config.Username = ""
+				_, err := config.Generate()
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("should return an error if the password is not set", func() {
+				config.Password = ""
+				_, err := config.Generate()
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Describe("#GenerateInfoData", func() {
+			It("should return an error since it is not implemented", func() {
+				_, err := config.GenerateInfoData()
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Describe("#GenerateFromInfoData", func() {
+			It("should return an error since it is not implemented", func() {
+				_, err := config.GenerateFromInfoData(nil)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Describe("#LoadFromSecretData", func() {
+			It("should return an error since it is not implemented", func() {
+				_, err := config.LoadFromSecretData(nil)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("DockerConfigJSON Object", func() {
+		Describe("#SecretData", func() {
+			It("should store the payload under the '.dockerconfigjson' data key", func() {
+				dockerConfigJSON := &DockerConfigJSON{JSON: []byte(`{"auths":{}}`)}
+				Expect(dockerConfigJSON.SecretData()).To(Equal(map[string][]byte{
+					DataKeyDockerConfigJSON: []byte(`{"auths":{}}`),
+				}))
+			})
+		})
+	})
+})
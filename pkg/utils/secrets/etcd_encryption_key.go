@@ -17,8 +17,15 @@ package secrets
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/gardener/gardener/pkg/utils/infodata"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
 )
 
 const (
@@ -26,21 +33,65 @@ const (
 	DataKeyEncryptionKeyName = "key"
 	// DataKeyEncryptionSecret is the key in a secret data holding the secret.
 	DataKeyEncryptionSecret = "secret"
+	// DataKeyRetainedEncryptionKeysCSV is the key in a secret data holding the CSV-encoded list of previously used
+	// keys that are retained for decryption purposes during a key rotation. Each row has the format
+	// '<key-name>,<secret>'.
+	DataKeyRetainedEncryptionKeysCSV = "retained_keys.csv"
+
+	// defaultMaxEncryptionKeys is the default value for ETCDEncryptionKeySecretConfig.MaxKeys, i.e. the maximum
+	// number of keys (the freshly generated one plus retained ones) kept in a generated secret if MaxKeys is unset.
+	// This corresponds to the previous behaviour of retaining exactly one old key.
+	defaultMaxEncryptionKeys = 2
+)
+
+// ETCDEncryptionKeyAlgorithm is a string alias for the etcd encryption provider that the generated key is used with.
+type ETCDEncryptionKeyAlgorithm string
+
+const (
+	// ETCDEncryptionKeyAlgorithmAESCBC selects the aescbc provider. It requires a 32 byte key and is the default if
+	// ETCDEncryptionKeySecretConfig.EncryptionAlgorithm is not set, preserving the behaviour of existing
+	// configurations that predate this field.
+	ETCDEncryptionKeyAlgorithmAESCBC ETCDEncryptionKeyAlgorithm = "aescbc"
+	// ETCDEncryptionKeyAlgorithmAESGCM selects the aesgcm provider. It accepts a 16, 24, or 32 byte key.
+	ETCDEncryptionKeyAlgorithmAESGCM ETCDEncryptionKeyAlgorithm = "aesgcm"
+	// ETCDEncryptionKeyAlgorithmSecretbox selects the secretbox provider. It requires a 32 byte key.
+	ETCDEncryptionKeyAlgorithmSecretbox ETCDEncryptionKeyAlgorithm = "secretbox"
 )
 
 // ETCDEncryptionKeySecretConfig contains the specification for a to-be-generated random key.
 type ETCDEncryptionKeySecretConfig struct {
 	Name         string
 	SecretLength int
+	// EncryptionAlgorithm selects the etcd encryption provider the generated key is used with. If empty, it defaults
+	// to ETCDEncryptionKeyAlgorithmAESCBC, preserving the behaviour of configurations that predate this field.
+	EncryptionAlgorithm ETCDEncryptionKeyAlgorithm
+	// RetainedKeys holds previously generated keys (newest first) that should still be usable for decrypting etcd
+	// data during a key rotation. The freshly generated key is always prepended in front of them. It is not
+	// considered for the config checksum (used to detect the need for regeneration), since it merely reflects
+	// pre-existing state rather than desired configuration.
+	RetainedKeys []ETCDEncryptionKeyEntry `hash:"ignore"`
+	// MaxKeys bounds the total number of keys (the freshly generated one plus RetainedKeys) kept in the generated
+	// secret. If zero, it defaults to defaultMaxEncryptionKeys.
+	MaxKeys int
 }
 
-// ETCDEncryptionKey contains the generated key.
-type ETCDEncryptionKey struct {
-	Name   string
+// ETCDEncryptionKeyEntry represents a single etcd encryption key/secret pair.
+type ETCDEncryptionKeyEntry struct {
 	Key    string
 	Secret string
 }
 
+// ETCDEncryptionKey contains the generated key.
+type ETCDEncryptionKey struct {
+	Name                string
+	Key                 string
+	Secret              string
+	EncryptionAlgorithm ETCDEncryptionKeyAlgorithm
+	// RetainedKeys holds additional, previously generated keys (newest first) that remain usable for decryption in
+	// addition to Key/Secret. It is empty by default, preserving the previous single-key behaviour.
+	RetainedKeys []ETCDEncryptionKeyEntry
+}
+
 // GetName returns the name of the secret.
 func (s *ETCDEncryptionKeySecretConfig) GetName() string {
 	return s.Name
@@ -48,18 +99,58 @@ func (s *ETCDEncryptionKeySecretConfig) GetName() string {
 
 // Generate implements ConfigInterface.
 func (s *ETCDEncryptionKeySecretConfig) Generate() (DataInterface, error) {
+	algorithm := s.EncryptionAlgorithm
+	if algorithm == "" {
+		algorithm = ETCDEncryptionKeyAlgorithmAESCBC
+	}
+
+	if err := validateETCDEncryptionKeyLength(algorithm, s.SecretLength); err != nil {
+		return nil, err
+	}
+
 	secret, err := GenerateRandomString(s.SecretLength)
 	if err != nil {
 		return nil, err
 	}
 
+	maxKeys := s.MaxKeys
+	if maxKeys <= 0 {
+		maxKeys = defaultMaxEncryptionKeys
+	}
+
+	retainedKeys := s.RetainedKeys
+	if len(retainedKeys) > maxKeys-1 {
+		retainedKeys = retainedKeys[:maxKeys-1]
+	}
+
 	return &ETCDEncryptionKey{
-		Name:   s.Name,
-		Key:    fmt.Sprintf("key%d", Clock.Now().Unix()),
-		Secret: secret,
+		Name:                s.Name,
+		Key:                 fmt.Sprintf("key%d", Clock.Now().Unix()),
+		Secret:              secret,
+		EncryptionAlgorithm: algorithm,
+		RetainedKeys:        retainedKeys,
 	}, nil
 }
 
+// validateETCDEncryptionKeyLength validates that keyLength is an acceptable key size (in bytes) for the given
+// algorithm: aescbc and secretbox require 32 bytes, while aesgcm accepts 16, 24, or 32 bytes.
+func validateETCDEncryptionKeyLength(algorithm ETCDEncryptionKeyAlgorithm, keyLength int) error {
+	switch algorithm {
+	case ETCDEncryptionKeyAlgorithmAESCBC, ETCDEncryptionKeyAlgorithmSecretbox:
+		if keyLength != 32 {
+			return fmt.Errorf("key length must be 32 bytes for %s, got %d", algorithm, keyLength)
+		}
+	case ETCDEncryptionKeyAlgorithmAESGCM:
+		if keyLength != 16 && keyLength != 24 && keyLength != 32 {
+			return fmt.Errorf("key length must be 16, 24, or 32 bytes for %s, got %d", algorithm, keyLength)
+		}
+	default:
+		return fmt.Errorf("unknown etcd encryption key algorithm %q", algorithm)
+	}
+
+	return nil
+}
+
 // GenerateInfoData implements ConfigInterface.
 func (s *ETCDEncryptionKeySecretConfig) GenerateInfoData() (infodata.InfoData, error) {
 	return nil, errors.New("not implemented")
@@ -77,8 +168,193 @@ func (s *ETCDEncryptionKeySecretConfig) LoadFromSecretData(_ map[string][]byte)
 
 // SecretData computes the data map which can be used in a Kubernetes secret.
 func (b *ETCDEncryptionKey) SecretData() map[string][]byte {
-	return map[string][]byte{
+	data := map[string][]byte{
 		DataKeyEncryptionKeyName: []byte(b.Key),
 		DataKeyEncryptionSecret:  []byte(b.Secret),
 	}
+
+	if len(b.RetainedKeys) > 0 {
+		rows := make([]string, 0, len(b.RetainedKeys))
+		for _, key := range b.RetainedKeys {
+			rows = append(rows, fmt.Sprintf("%s,%s", key.Key, key.Secret))
+		}
+		data[DataKeyRetainedEncryptionKeysCSV] = []byte(strings.Join(rows, "\n"))
+	}
+
+	return data
+}
+
+// KMSProviderConfig configures the KMS provider used by NewAESCBCToKMSEncryptionConfiguration.
+type KMSProviderConfig struct {
+	// Name is the name of the KMS plugin to be used.
+	Name string
+	// Endpoint is the gRPC server listening address of the KMS plugin.
+	Endpoint string
+	// CacheSize is the maximum number of secrets which are cached in memory. If nil, the apiserver default is used.
+	CacheSize *int32
+}
+
+// NewAESCBCToKMSEncryptionConfiguration builds an EncryptionConfiguration for migrating etcd encryption from aescbc
+// to a KMS provider: the KMS provider is listed first so that it is used to encrypt new data, the aescbc key(s)
+// loaded from aescbcSecretData (as written by ETCDEncryptionKeySecretConfig.SecretData for an aescbc key) are kept
+// as a secondary provider so that data encrypted with the old key can still be decrypted, and the identity provider
+// remains last.
+func NewAESCBCToKMSEncryptionConfiguration(kms KMSProviderConfig, aescbcSecretData map[string][]byte) (*apiserverconfigv1.EncryptionConfiguration, error) {
+	retainedKeys, err := LoadRetainedEncryptionKeysFromCSV(aescbcSecretData[DataKeyRetainedEncryptionKeysCSV])
+	if err != nil {
+		return nil, err
+	}
+
+	aescbcKeys := append([]ETCDEncryptionKeyEntry{{
+		Key:    string(aescbcSecretData[DataKeyEncryptionKeyName]),
+		Secret: string(aescbcSecretData[DataKeyEncryptionSecret]),
+	}}, retainedKeys...)
+
+	aescbcProvider, err := providerConfigurationForKeys(ETCDEncryptionKeyAlgorithmAESCBC, aescbcKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiserverconfigv1.EncryptionConfiguration{
+		Resources: []apiserverconfigv1.ResourceConfiguration{{
+			Resources: []string{"secrets"},
+			Providers: []apiserverconfigv1.ProviderConfiguration{
+				{
+					KMS: &apiserverconfigv1.KMSConfiguration{
+						Name:      kms.Name,
+						Endpoint:  kms.Endpoint,
+						CacheSize: kms.CacheSize,
+					},
+				},
+				aescbcProvider,
+				{
+					Identity: &apiserverconfigv1.IdentityConfiguration{},
+				},
+			},
+		}},
+	}, nil
+}
+
+// BuildEncryptionConfiguration builds and YAML-encodes an EncryptionConfiguration for the "secrets" resource, using
+// the given keys (newest first, as produced by ETCDEncryptionKeySecretConfig.Generate plus RetainedKeys) with the
+// given algorithm as the sole key-based provider, followed by the identity provider as a fallback for reading
+// unencrypted data. It is the exported counterpart of the construction logic used internally by the secrets manager
+// (see pkg/operation/botanist/component/kubeapiserver), so that other components, e.g. migration tooling, do not
+// have to duplicate the provider-selection logic. Use ParseEncryptionConfiguration to reverse this.
+func BuildEncryptionConfiguration(keys []ETCDEncryptionKeyEntry, algorithm ETCDEncryptionKeyAlgorithm) ([]byte, error) {
+	provider, err := providerConfigurationForKeys(algorithm, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return EncodeEncryptionConfiguration(&apiserverconfigv1.EncryptionConfiguration{
+		Resources: []apiserverconfigv1.ResourceConfiguration{{
+			Resources: []string{"secrets"},
+			Providers: []apiserverconfigv1.ProviderConfiguration{
+				provider,
+				{
+					Identity: &apiserverconfigv1.IdentityConfiguration{},
+				},
+			},
+		}},
+	})
+}
+
+// ParseEncryptionConfiguration parses a YAML- or JSON-encoded EncryptionConfiguration as produced by
+// BuildEncryptionConfiguration, and returns the keys and algorithm of the first key-based provider it finds for the
+// "secrets" resource.
+func ParseEncryptionConfiguration(data []byte) ([]ETCDEncryptionKeyEntry, ETCDEncryptionKeyAlgorithm, error) {
+	scheme := runtime.NewScheme()
+	if err := apiserverconfigv1.AddToScheme(scheme); err != nil {
+		return nil, "", err
+	}
+
+	obj, _, err := serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(data, nil, &apiserverconfigv1.EncryptionConfiguration{})
+	if err != nil {
+		return nil, "", err
+	}
+
+	encryptionConfiguration, ok := obj.(*apiserverconfigv1.EncryptionConfiguration)
+	if !ok || len(encryptionConfiguration.Resources) == 0 {
+		return nil, "", fmt.Errorf("encryption configuration does not contain any resource configuration")
+	}
+
+	for _, provider := range encryptionConfiguration.Resources[0].Providers {
+		switch {
+		case provider.AESCBC != nil:
+			return encryptionKeyEntries(provider.AESCBC.Keys), ETCDEncryptionKeyAlgorithmAESCBC, nil
+		case provider.AESGCM != nil:
+			return encryptionKeyEntries(provider.AESGCM.Keys), ETCDEncryptionKeyAlgorithmAESGCM, nil
+		case provider.Secretbox != nil:
+			return encryptionKeyEntries(provider.Secretbox.Keys), ETCDEncryptionKeyAlgorithmSecretbox, nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("encryption configuration does not contain a supported key-based provider")
+}
+
+// providerConfigurationForKeys builds the ProviderConfiguration block matching the given etcd encryption algorithm
+// and keys.
+func providerConfigurationForKeys(algorithm ETCDEncryptionKeyAlgorithm, keys []ETCDEncryptionKeyEntry) (apiserverconfigv1.ProviderConfiguration, error) {
+	apiKeys := make([]apiserverconfigv1.Key, 0, len(keys))
+	for _, key := range keys {
+		apiKeys = append(apiKeys, apiserverconfigv1.Key{Name: key.Key, Secret: key.Secret})
+	}
+
+	switch algorithm {
+	case ETCDEncryptionKeyAlgorithmAESCBC, "":
+		return apiserverconfigv1.ProviderConfiguration{AESCBC: &apiserverconfigv1.AESConfiguration{Keys: apiKeys}}, nil
+	case ETCDEncryptionKeyAlgorithmAESGCM:
+		return apiserverconfigv1.ProviderConfiguration{AESGCM: &apiserverconfigv1.AESConfiguration{Keys: apiKeys}}, nil
+	case ETCDEncryptionKeyAlgorithmSecretbox:
+		return apiserverconfigv1.ProviderConfiguration{Secretbox: &apiserverconfigv1.SecretboxConfiguration{Keys: apiKeys}}, nil
+	default:
+		return apiserverconfigv1.ProviderConfiguration{}, fmt.Errorf("unsupported etcd encryption key algorithm %q", algorithm)
+	}
+}
+
+func encryptionKeyEntries(keys []apiserverconfigv1.Key) []ETCDEncryptionKeyEntry {
+	entries := make([]ETCDEncryptionKeyEntry, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, ETCDEncryptionKeyEntry{Key: key.Name, Secret: key.Secret})
+	}
+	return entries
+}
+
+// EncodeEncryptionConfiguration serializes the given EncryptionConfiguration to YAML. Since the struct's resources,
+// providers, and keys are always assembled in a fixed order by this package's constructors, encoding the same
+// configuration repeatedly yields byte-identical output, which avoids spurious secret updates on every reconcile.
+func EncodeEncryptionConfiguration(encryptionConfiguration *apiserverconfigv1.EncryptionConfiguration) ([]byte, error) {
+	scheme := runtime.NewScheme()
+	if err := apiserverconfigv1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	ser := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{Yaml: true, Pretty: false, Strict: false})
+	versions := schema.GroupVersions([]schema.GroupVersion{apiserverconfigv1.SchemeGroupVersion})
+	codec := serializer.NewCodecFactory(scheme).CodecForVersions(ser, ser, versions, versions)
+
+	return runtime.Encode(codec, encryptionConfiguration)
+}
+
+// LoadRetainedEncryptionKeysFromCSV parses the CSV-encoded list of retained encryption keys as written by
+// SecretData under DataKeyRetainedEncryptionKeysCSV.
+func LoadRetainedEncryptionKeysFromCSV(data []byte) ([]ETCDEncryptionKeyEntry, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	lines := strings.Split(string(data), "\n")
+	keys := make([]ETCDEncryptionKeyEntry, 0, len(lines))
+
+	for _, line := range lines {
+		csv := strings.Split(line, ",")
+		if len(csv) != 2 {
+			return nil, fmt.Errorf("invalid CSV for loading retained etcd encryption keys: %s", string(data))
+		}
+
+		keys = append(keys, ETCDEncryptionKeyEntry{Key: csv[0], Secret: csv[1]})
+	}
+
+	return keys, nil
 }
@@ -15,16 +15,20 @@
 package secrets_test
 
 import (
+	"strings"
+
 	. "github.com/gardener/gardener/pkg/utils/secrets"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	gomegatypes "github.com/onsi/gomega/types"
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
 )
 
 var _ = Describe("Etcd Encryption Key Secrets", func() {
 	var (
 		name         = "etcd encryption key"
-		secretLength = 17
+		secretLength = 32
 	)
 
 	Describe("Configuration", func() {
@@ -53,7 +57,55 @@ var _ = Describe("Etcd Encryption Key Secrets", func() {
 
 				Expect(etcdEncryptionKey.Name).To(Equal(name))
 				Expect(etcdEncryptionKey.Key).To(Equal("key-62135596800"))
-				Expect(etcdEncryptionKey.Secret).To(Equal("_________________"))
+				Expect(etcdEncryptionKey.Secret).To(Equal(strings.Repeat("_", secretLength)))
+				Expect(etcdEncryptionKey.EncryptionAlgorithm).To(Equal(ETCDEncryptionKeyAlgorithmAESCBC))
+			})
+
+			DescribeTable("#EncryptionAlgorithm key length validation",
+				func(algorithm ETCDEncryptionKeyAlgorithm, length int, matcher gomegatypes.GomegaMatcher) {
+					config.EncryptionAlgorithm = algorithm
+					config.SecretLength = length
+
+					_, err := config.Generate()
+					Expect(err).To(matcher)
+				},
+
+				Entry("aescbc with 32 byte key", ETCDEncryptionKeyAlgorithmAESCBC, 32, Not(HaveOccurred())),
+				Entry("aescbc with 16 byte key", ETCDEncryptionKeyAlgorithmAESCBC, 16, HaveOccurred()),
+				Entry("aesgcm with 16 byte key", ETCDEncryptionKeyAlgorithmAESGCM, 16, Not(HaveOccurred())),
+				Entry("aesgcm with 24 byte key", ETCDEncryptionKeyAlgorithmAESGCM, 24, Not(HaveOccurred())),
+				Entry("aesgcm with 32 byte key", ETCDEncryptionKeyAlgorithmAESGCM, 32, Not(HaveOccurred())),
+				Entry("aesgcm with 17 byte key", ETCDEncryptionKeyAlgorithmAESGCM, 17, HaveOccurred()),
+				Entry("secretbox with 32 byte key", ETCDEncryptionKeyAlgorithmSecretbox, 32, Not(HaveOccurred())),
+				Entry("secretbox with 16 byte key", ETCDEncryptionKeyAlgorithmSecretbox, 16, HaveOccurred()),
+				Entry("aescbc with zero-length key", ETCDEncryptionKeyAlgorithmAESCBC, 0, HaveOccurred()),
+				Entry("aesgcm with zero-length key", ETCDEncryptionKeyAlgorithmAESGCM, 0, HaveOccurred()),
+			)
+
+			It("should carry over the retained keys", func() {
+				config.RetainedKeys = []ETCDEncryptionKeyEntry{{Key: "key-1", Secret: "secret-1"}}
+
+				obj, err := config.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				etcdEncryptionKey, ok := obj.(*ETCDEncryptionKey)
+				Expect(ok).To(BeTrue())
+				Expect(etcdEncryptionKey.RetainedKeys).To(Equal(config.RetainedKeys))
+			})
+
+			It("should bound the retained keys by MaxKeys", func() {
+				config.MaxKeys = 2
+				config.RetainedKeys = []ETCDEncryptionKeyEntry{
+					{Key: "key-1", Secret: "secret-1"},
+					{Key: "key-2", Secret: "secret-2"},
+				}
+
+				obj, err := config.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				etcdEncryptionKey, ok := obj.(*ETCDEncryptionKey)
+				Expect(ok).To(BeTrue())
+				Expect(etcdEncryptionKey.RetainedKeys).To(Equal([]ETCDEncryptionKeyEntry{{Key: "key-1", Secret: "secret-1"}}))
 			})
 		})
 
@@ -67,9 +119,164 @@ var _ = Describe("Etcd Encryption Key Secrets", func() {
 
 				Expect(etcdEncryptionKey.SecretData()).To(Equal(map[string][]byte{
 					"key":    []byte("key-62135596800"),
-					"secret": []byte("_________________"),
+					"secret": []byte(strings.Repeat("_", secretLength)),
+				}))
+			})
+
+			It("should include the CSV-encoded retained keys if present", func() {
+				config.MaxKeys = 3
+				config.RetainedKeys = []ETCDEncryptionKeyEntry{
+					{Key: "key-1", Secret: "secret-1"},
+					{Key: "key-2", Secret: "secret-2"},
+				}
+
+				obj, err := config.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				etcdEncryptionKey, ok := obj.(*ETCDEncryptionKey)
+				Expect(ok).To(BeTrue())
+
+				Expect(etcdEncryptionKey.SecretData()).To(Equal(map[string][]byte{
+					"key":               []byte("key-62135596800"),
+					"secret":            []byte(strings.Repeat("_", secretLength)),
+					"retained_keys.csv": []byte("key-1,secret-1\nkey-2,secret-2"),
 				}))
 			})
 		})
 	})
+
+	Describe("#LoadRetainedEncryptionKeysFromCSV", func() {
+		It("should return nil for empty data", func() {
+			keys, err := LoadRetainedEncryptionKeysFromCSV(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(BeNil())
+		})
+
+		It("should parse the CSV-encoded keys", func() {
+			keys, err := LoadRetainedEncryptionKeysFromCSV([]byte("key-1,secret-1\nkey-2,secret-2"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]ETCDEncryptionKeyEntry{
+				{Key: "key-1", Secret: "secret-1"},
+				{Key: "key-2", Secret: "secret-2"},
+			}))
+		})
+
+		It("should return an error for malformed data", func() {
+			_, err := LoadRetainedEncryptionKeysFromCSV([]byte("not-a-valid-row"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#NewAESCBCToKMSEncryptionConfiguration", func() {
+		It("should place the KMS provider first and keep the old aescbc key for decryption", func() {
+			cacheSize := int32(500)
+			kms := KMSProviderConfig{
+				Name:      "my-kms",
+				Endpoint:  "unix:///var/run/kms-provider.sock",
+				CacheSize: &cacheSize,
+			}
+
+			aescbcSecretData := map[string][]byte{
+				DataKeyEncryptionKeyName:         []byte("key1"),
+				DataKeyEncryptionSecret:          []byte("secret1"),
+				DataKeyRetainedEncryptionKeysCSV: []byte("key0,secret0"),
+			}
+
+			encryptionConfiguration, err := NewAESCBCToKMSEncryptionConfiguration(kms, aescbcSecretData)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(encryptionConfiguration.Resources).To(HaveLen(1))
+			Expect(encryptionConfiguration.Resources[0].Resources).To(ConsistOf("secrets"))
+
+			providers := encryptionConfiguration.Resources[0].Providers
+			Expect(providers).To(HaveLen(3))
+
+			Expect(providers[0].KMS).To(Equal(&apiserverconfigv1.KMSConfiguration{
+				Name:      "my-kms",
+				Endpoint:  "unix:///var/run/kms-provider.sock",
+				CacheSize: &cacheSize,
+			}))
+
+			Expect(providers[1].AESCBC).To(Equal(&apiserverconfigv1.AESConfiguration{
+				Keys: []apiserverconfigv1.Key{
+					{Name: "key1", Secret: "secret1"},
+					{Name: "key0", Secret: "secret0"},
+				},
+			}))
+
+			Expect(providers[2].Identity).To(Equal(&apiserverconfigv1.IdentityConfiguration{}))
+		})
+
+		It("should return an error if the retained keys CSV is malformed", func() {
+			_, err := NewAESCBCToKMSEncryptionConfiguration(KMSProviderConfig{}, map[string][]byte{
+				DataKeyRetainedEncryptionKeysCSV: []byte("not-a-valid-row"),
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#BuildEncryptionConfiguration and #ParseEncryptionConfiguration", func() {
+		It("should round-trip a multi-key configuration through build and parse", func() {
+			keys := []ETCDEncryptionKeyEntry{
+				{Key: "key2", Secret: "secret2"},
+				{Key: "key1", Secret: "secret1"},
+				{Key: "key0", Secret: "secret0"},
+			}
+
+			data, err := BuildEncryptionConfiguration(keys, ETCDEncryptionKeyAlgorithmAESGCM)
+			Expect(err).NotTo(HaveOccurred())
+
+			parsedKeys, algorithm, err := ParseEncryptionConfiguration(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(algorithm).To(Equal(ETCDEncryptionKeyAlgorithmAESGCM))
+			Expect(parsedKeys).To(Equal(keys))
+		})
+
+		DescribeTable("building the provider block for the given algorithm",
+			func(algorithm ETCDEncryptionKeyAlgorithm, matcher gomegatypes.GomegaMatcher) {
+				keys := []ETCDEncryptionKeyEntry{{Key: "key-1", Secret: "secret-1"}}
+
+				data, err := BuildEncryptionConfiguration(keys, algorithm)
+				Expect(err).To(matcher)
+				if err == nil {
+					Expect(string(data)).To(ContainSubstring("secret-1"))
+				}
+			},
+
+			Entry("aescbc", ETCDEncryptionKeyAlgorithmAESCBC, Not(HaveOccurred())),
+			Entry("aesgcm", ETCDEncryptionKeyAlgorithmAESGCM, Not(HaveOccurred())),
+			Entry("secretbox", ETCDEncryptionKeyAlgorithmSecretbox, Not(HaveOccurred())),
+			Entry("empty (defaults to aescbc)", ETCDEncryptionKeyAlgorithm(""), Not(HaveOccurred())),
+			Entry("unknown", ETCDEncryptionKeyAlgorithm("unknown"), HaveOccurred()),
+		)
+
+		It("should return an error when parsing data without a resource configuration", func() {
+			_, _, err := ParseEncryptionConfiguration([]byte("apiVersion: apiserver.config.k8s.io/v1\nkind: EncryptionConfiguration\n"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#EncodeEncryptionConfiguration", func() {
+		It("should produce byte-identical output for repeated encodings of the same configuration", func() {
+			aescbcSecretData := map[string][]byte{
+				DataKeyEncryptionKeyName:         []byte("key2"),
+				DataKeyEncryptionSecret:          []byte("secret2"),
+				DataKeyRetainedEncryptionKeysCSV: []byte("key1,secret1\nkey0,secret0"),
+			}
+			kms := KMSProviderConfig{Name: "my-kms", Endpoint: "unix:///var/run/kms-provider.sock"}
+
+			encryptionConfiguration1, err := NewAESCBCToKMSEncryptionConfiguration(kms, aescbcSecretData)
+			Expect(err).NotTo(HaveOccurred())
+			data1, err := EncodeEncryptionConfiguration(encryptionConfiguration1)
+			Expect(err).NotTo(HaveOccurred())
+
+			encryptionConfiguration2, err := NewAESCBCToKMSEncryptionConfiguration(kms, aescbcSecretData)
+			Expect(err).NotTo(HaveOccurred())
+			data2, err := EncodeEncryptionConfiguration(encryptionConfiguration2)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(data1).To(Equal(data2))
+			Expect(string(data1)).To(ContainSubstring("key2"))
+		})
+	})
 })
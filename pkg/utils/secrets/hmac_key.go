@@ -0,0 +1,100 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gardener/gardener/pkg/utils/infodata"
+)
+
+// DataKeyHMACSecretKey is the default key in a secret data holding a generated HMAC key.
+const DataKeyHMACSecretKey = "hmac-secret-key"
+
+// minHMACKeyLength is the minimum size (in bytes) accepted for a generated HMAC key.
+const minHMACKeyLength = 32
+
+// HMACKeySecretConfig contains the specification for a to-be-generated HMAC key, e.g. for signing webhook or
+// bootstrap tokens. The key is a random byte sequence of the configured KeyLength, generated via GenerateRandomBytes.
+type HMACKeySecretConfig struct {
+	Name string
+
+	// KeyLength is the length (in bytes) of the generated key. Must be at least minHMACKeyLength.
+	KeyLength int
+
+	// DataKey overrides the key under which the generated key is stored in the secret. Defaults to
+	// DataKeyHMACSecretKey.
+	DataKey string
+}
+
+// HMACKey contains the name and the generated HMAC key.
+type HMACKey struct {
+	Name string
+	Key  []byte
+
+	// DataKey is the data key under which Key is stored. Defaults to DataKeyHMACSecretKey.
+	DataKey string
+}
+
+// GetName returns the name of the secret.
+func (s *HMACKeySecretConfig) GetName() string {
+	return s.Name
+}
+
+// Generate implements ConfigInterface.
+func (s *HMACKeySecretConfig) Generate() (DataInterface, error) {
+	if s.KeyLength < minHMACKeyLength {
+		return nil, fmt.Errorf("HMAC key length must be at least %d bytes for secret %q", minHMACKeyLength, s.Name)
+	}
+
+	key, err := GenerateRandomBytes(s.KeyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HMACKey{
+		Name:    s.Name,
+		Key:     key,
+		DataKey: s.DataKey,
+	}, nil
+}
+
+// GenerateInfoData implements ConfigInterface.
+func (s *HMACKeySecretConfig) GenerateInfoData() (infodata.InfoData, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GenerateFromInfoData implements ConfigInterface.
+func (s *HMACKeySecretConfig) GenerateFromInfoData(_ infodata.InfoData) (DataInterface, error) {
+	return nil, errors.New("not implemented")
+}
+
+// LoadFromSecretData implements infodata.Loader.
+func (s *HMACKeySecretConfig) LoadFromSecretData(_ map[string][]byte) (infodata.InfoData, error) {
+	return nil, errors.New("not implemented")
+}
+
+// SecretData computes the data map which can be used in a Kubernetes secret.
+func (k *HMACKey) SecretData() map[string][]byte {
+	dataKey := k.DataKey
+	if dataKey == "" {
+		dataKey = DataKeyHMACSecretKey
+	}
+
+	return map[string][]byte{
+		dataKey: k.Key,
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets_test
+
+import (
+	. "github.com/gardener/gardener/pkg/utils/secrets"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HMAC Key Secrets", func() {
+	Describe("HMAC Key Secret Configuration", func() {
+		var config *HMACKeySecretConfig
+
+		BeforeEach(func() {
+			config = &HMACKeySecretConfig{
+				Name:      "hmac-key",
+				KeyLength: 32,
+			}
+		})
+
+		Describe("#Generate", func() {
+			It("should generate a key of the configured length", func() {
+				obj, err := config.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				hmacKey, ok := obj.(*HMACKey)
+				Expect(ok).To(BeTrue())
+				Expect(hmacKey.Key).To(HaveLen(32))
+			})
+
+			It("should generate a different key on every call", func() {
+				obj1, err := config.Generate()
+				Expect(err).NotTo(HaveOccurred())
+				obj2, err := config.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(obj1.(*HMACKey).Key).NotTo(Equal(obj2.(*HMACKey).Key))
+			})
+
+			It("should return an error if the key length is below the minimum", func() {
+				config.KeyLength = 16
+				_, err := config.Generate()
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Describe("#GenerateInfoData", func() {
+			It("should return an error since it is not implemented", func() {
+				_, err := config.GenerateInfoData()
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Describe("#GenerateFromInfoData", func() {
+			It("should return an error since it is not implemented", func() {
+				_, err := config.GenerateFromInfoData(nil)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Describe("#LoadFromSecretData", func() {
+			It("should return an error since it is not implemented", func() {
+				_, err := config.LoadFromSecretData(nil)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("HMACKey Object", func() {
+		Describe("#SecretData", func() {
+			It("should store the key under the default data key", func() {
+				hmacKey := &HMACKey{Key: []byte("foo")}
+				Expect(hmacKey.SecretData()).To(Equal(map[string][]byte{
+					DataKeyHMACSecretKey: []byte("foo"),
+				}))
+			})
+
+			It("should store the key under the configured data key", func() {
+				hmacKey := &HMACKey{Key: []byte("foo"), DataKey: "my.key"}
+				Expect(hmacKey.SecretData()).To(Equal(map[string][]byte{
+					"my.key": []byte("foo"),
+				}))
+			})
+		})
+	})
+})
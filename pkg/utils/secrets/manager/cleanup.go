@@ -38,10 +38,11 @@ func (m *manager) Cleanup(ctx context.Context) error {
 			name = v
 		}
 
-		if secrets, found := m.getFromStore(name); found &&
+		if secrets, found := m.getFromStore(secret.Namespace, name); found &&
 			(secrets.current.obj.Name == secret.Name ||
 				(secrets.old != nil && secrets.old.obj.Name == secret.Name) ||
-				(secrets.bundle != nil && secrets.bundle.obj.Name == secret.Name)) {
+				(secrets.bundle != nil && secrets.bundle.obj.Name == secret.Name) ||
+				isRetained(secrets.retained, secret.Name)) {
 			continue
 		}
 
@@ -53,3 +54,14 @@ func (m *manager) Cleanup(ctx context.Context) error {
 
 	return flow.Parallel(fns...)(ctx)
 }
+
+// isRetained reports whether retained (the secrets kept by a KeepLastN rotation strategy) contains a secret with the
+// given name.
+func isRetained(retained []secretInfo, name string) bool {
+	for _, info := range retained {
+		if info.obj.Name == name {
+			return true
+		}
+	}
+	return false
+}
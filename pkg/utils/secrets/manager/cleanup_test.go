@@ -83,12 +83,12 @@ var _ = Describe("Cleanup", func() {
 				Expect(fakeClient.Create(ctx, secrets[i])).To(Succeed())
 			}
 
-			Expect(m.addToStore("first", secrets[0], current)).To(Succeed())
-			Expect(m.addToStore("first", secrets[1], old)).To(Succeed())
-			Expect(m.addToStore("first", secrets[2], bundle)).To(Succeed())
-			Expect(m.addToStore("second", secrets[3], current)).To(Succeed())
-			Expect(m.addToStore("third", secrets[4], current)).To(Succeed())
-			Expect(m.addToStore("third", secrets[5], old)).To(Succeed())
+			Expect(m.addToStore(namespace, "first", secrets[0], current)).To(Succeed())
+			Expect(m.addToStore(namespace, "first", secrets[1], old)).To(Succeed())
+			Expect(m.addToStore(namespace, "first", secrets[2], bundle)).To(Succeed())
+			Expect(m.addToStore(namespace, "second", secrets[3], current)).To(Succeed())
+			Expect(m.addToStore(namespace, "third", secrets[4], current)).To(Succeed())
+			Expect(m.addToStore(namespace, "third", secrets[5], old)).To(Succeed())
 
 			Expect(m.Cleanup(ctx)).To(Succeed())
 
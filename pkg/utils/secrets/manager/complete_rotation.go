@@ -0,0 +1,76 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CompleteRotation deletes the 'old' secret tracked for the secret with the given name, and clears it from the
+// internal store, but only once at least gracePeriod has elapsed since the current secret took over (as measured by
+// the current secret's 'issued-at-time' label and the manager's clock). This allows a KeepOld rotation, which keeps
+// the previous secret around so that consumers still holding a reference to it keep working, to eventually be
+// completed once every consumer had a chance to pick up the new secret. It is a no-op if there is no 'old' secret
+// tracked for the given name. If the grace period has not yet elapsed, it instead marks the current secret's
+// LabelKeyRotationPhase as LabelValueRotationPhaseCompleting to record that completion was requested. Once the old
+// secret is actually deleted, the current secret's LabelKeyRotationPhase is set to LabelValueRotationPhaseCompleted.
+func (m *manager) CompleteRotation(ctx context.Context, name string, gracePeriod time.Duration) error {
+	secrets, found := m.getFromStore(m.namespace, name)
+	if !found || secrets.old == nil {
+		return nil
+	}
+
+	issuedAt, err := strconv.ParseInt(secrets.current.obj.Labels[LabelKeyIssuedAtTime], 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed parsing issued-at-time label of current secret %q: %w", secrets.current.obj.Name, err)
+	}
+
+	if m.clock.Now().Before(time.Unix(issuedAt, 0).Add(gracePeriod)) {
+		return m.patchRotationPhase(ctx, secrets.current.obj, LabelValueRotationPhaseCompleting)
+	}
+
+	oldSecret := secrets.old.obj
+
+	m.logger.Info("Completing rotation by deleting old secret whose grace period has elapsed", "namespace", oldSecret.Namespace, "name", oldSecret.Name)
+	if err := client.IgnoreNotFound(m.client.Delete(ctx, oldSecret)); err != nil {
+		return err
+	}
+
+	if err := m.patchRotationPhase(ctx, secrets.current.obj, LabelValueRotationPhaseCompleted); err != nil {
+		return err
+	}
+
+	m.removeOldFromStore(m.namespace, name)
+	return nil
+}
+
+// patchRotationPhase sets the LabelKeyRotationPhase label to phase on the given secret, unless it is already set to
+// that value.
+func (m *manager) patchRotationPhase(ctx context.Context, secret *corev1.Secret, phase string) error {
+	if secret.Labels[LabelKeyRotationPhase] == phase {
+		return nil
+	}
+
+	patch := client.MergeFrom(secret.DeepCopy())
+	metav1.SetMetaDataLabel(&secret.ObjectMeta, LabelKeyRotationPhase, phase)
+	return m.client.Patch(ctx, secret, patch)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/gardener/gardener/pkg/utils"
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// LabelKeyCRLFor is a constant for a key of a label on a Secret describing the name of the CA a CRL was issued
+	// for.
+	LabelKeyCRLFor = "crl-for"
+	// DataKeyCRL is the key in a secret data holding a DER-encoded certificate revocation list.
+	DataKeyCRL = "crl"
+
+	nameSuffixCRL = "-crl"
+	// crlValidity is the duration for which a generated CRL is valid before it must be regenerated.
+	crlValidity = 24 * time.Hour
+)
+
+// RevokedCert describes a certificate which should be listed as revoked in a CRL.
+type RevokedCert struct {
+	// SerialNumber is the serial number of the revoked certificate.
+	SerialNumber *big.Int
+	// RevocationTime is the time at which the certificate was revoked.
+	RevocationTime time.Time
+}
+
+// GenerateCRL generates a DER-encoded certificate revocation list signed by the private key of the CA with the given
+// name, listing the provided revoked certificates. The CRL is stored in a secret labeled 'crl-for: <caName>'.
+func (m *manager) GenerateCRL(ctx context.Context, caName string, revoked []RevokedCert) (*corev1.Secret, error) {
+	secrets, found := m.getFromStore(m.namespace, caName)
+	if !found {
+		return nil, fmt.Errorf("secrets for name %q not found in internal store", caName)
+	}
+
+	ca, err := secretutils.LoadCertificate(caName, secrets.current.obj.Data[secretutils.DataKeyPrivateKeyCA], secrets.current.obj.Data[secretutils.DataKeyCertificateCA])
+	if err != nil {
+		return nil, fmt.Errorf("failed loading CA certificate %q: %w", caName, err)
+	}
+
+	revokedCertificates := make([]pkix.RevokedCertificate, 0, len(revoked))
+	for _, r := range revoked {
+		revokedCertificates = append(revokedCertificates, pkix.RevokedCertificate{
+			SerialNumber:   r.SerialNumber,
+			RevocationTime: r.RevocationTime,
+		})
+	}
+
+	now := m.clock.Now()
+
+	crlDER, err := ca.Certificate.CreateCRL(utils.RandSource, ca.PrivateKey, revokedCertificates, now, now.Add(crlValidity))
+	if err != nil {
+		return nil, fmt.Errorf("failed creating CRL for CA %q: %w", caName, err)
+	}
+
+	objectMeta := metav1.ObjectMeta{
+		Name:      caName + nameSuffixCRL,
+		Namespace: m.namespace,
+		Labels: map[string]string{
+			LabelKeyName:            caName + nameSuffixCRL,
+			LabelKeyManagedBy:       LabelValueSecretsManager,
+			LabelKeyManagerIdentity: m.identity,
+			LabelKeyCRLFor:          caName,
+		},
+	}
+
+	secret := Secret(objectMeta, map[string][]byte{DataKeyCRL: crlDER})
+
+	if err := m.client.Create(ctx, secret); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return nil, err
+		}
+
+		existing := &corev1.Secret{}
+		if err := m.client.Get(ctx, client.ObjectKeyFromObject(secret), existing); err != nil {
+			return nil, err
+		}
+
+		patch := client.MergeFrom(existing.DeepCopy())
+		existing.Data = secret.Data
+		existing.Labels = secret.Labels
+		if err := m.client.Patch(ctx, existing, patch); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"crypto/x509"
+	"math/big"
+	"time"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("CRL", func() {
+	var (
+		ctx        = context.TODO()
+		namespace  = "shoot--foo--bar"
+		identity   = "test"
+		caName     = "ca-test"
+		fakeClient client.Client
+		fakeClock  = clock.NewFakeClock(time.Time{})
+
+		m *manager
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetesscheme.Scheme).Build()
+
+		mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+	})
+
+	Describe("#GenerateCRL", func() {
+		It("should generate a CRL that lists the revoked serial number", func() {
+			caConfig := &secretutils.CertificateSecretConfig{
+				Name:       caName,
+				CommonName: caName,
+				CertType:   secretutils.CACert,
+			}
+
+			_, err := m.Generate(ctx, caConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			serialNumber := big.NewInt(1337)
+			secret, err := m.GenerateCRL(ctx, caName, []RevokedCert{{
+				SerialNumber:   serialNumber,
+				RevocationTime: fakeClock.Now(),
+			}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Labels).To(HaveKeyWithValue(LabelKeyCRLFor, caName))
+
+			crl, err := x509.ParseCRL(secret.Data[DataKeyCRL])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(crl.TBSCertList.RevokedCertificates).To(HaveLen(1))
+			Expect(crl.TBSCertList.RevokedCertificates[0].SerialNumber).To(Equal(serialNumber))
+		})
+
+		It("should return an error if the CA is not found in the internal store", func() {
+			_, err := m.GenerateCRL(ctx, "unknown-ca", nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
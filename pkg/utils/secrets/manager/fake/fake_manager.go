@@ -16,6 +16,7 @@ package fake
 
 import (
 	"context"
+	"time"
 
 	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
@@ -24,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -47,6 +49,18 @@ func New(client client.Client, namespace string) *fakeManager {
 	}
 }
 
+func (m *fakeManager) Identity() string {
+	return ManagerIdentity
+}
+
+func (m *fakeManager) Namespace() string {
+	return m.namespace
+}
+
+func (m *fakeManager) LabelSelector(_ ...secretsmanager.SelectorOption) client.MatchingLabelsSelector {
+	return client.MatchingLabelsSelector{Selector: labels.Everything()}
+}
+
 func (m *fakeManager) Get(name string, opts ...secretsmanager.GetOption) (*corev1.Secret, bool) {
 	options := &secretsmanager.GetOptions{}
 	options.ApplyOptions(opts)
@@ -65,13 +79,21 @@ func (m *fakeManager) Get(name string, opts ...secretsmanager.GetOption) (*corev
 	}, true
 }
 
+func (m *fakeManager) GetBundle(name string, opts ...secretsmanager.GetOption) (*corev1.Secret, bool) {
+	return m.Get(name, append(opts, secretsmanager.Bundle)...)
+}
+
+func (m *fakeManager) GetRetained(_ string, _ ...secretsmanager.GetOption) []*corev1.Secret {
+	return nil
+}
+
 func (m *fakeManager) Generate(ctx context.Context, config secretutils.ConfigInterface, opts ...secretsmanager.GenerateOption) (*corev1.Secret, error) {
 	options := &secretsmanager.GenerateOptions{}
 	if err := options.ApplyOptions(m, config, opts); err != nil {
 		return nil, err
 	}
 
-	objectMeta, err := secretsmanager.ObjectMeta(m.namespace, ManagerIdentity, config, true, "", nil, nil, &options.Persist, nil)
+	objectMeta, err := secretsmanager.ObjectMeta(m.namespace, ManagerIdentity, config, true, "", nil, nil, &options.Persist, nil, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -105,6 +127,82 @@ func (m *fakeManager) Generate(ctx context.Context, config secretutils.ConfigInt
 	return secret, nil
 }
 
+func (m *fakeManager) ComputeSecretName(config secretutils.ConfigInterface, opts ...secretsmanager.GenerateOption) (string, error) {
+	options := &secretsmanager.GenerateOptions{}
+	if err := options.ApplyOptions(m, config, opts); err != nil {
+		return "", err
+	}
+
+	objectMeta, err := secretsmanager.ObjectMeta(m.namespace, ManagerIdentity, config, true, "", nil, nil, &options.Persist, nil, "", nil)
+	if err != nil {
+		return "", err
+	}
+
+	return objectMeta.Name, nil
+}
+
 func (m *fakeManager) Cleanup(_ context.Context) error {
 	return nil
 }
+
+func (m *fakeManager) CompleteRotation(_ context.Context, _ string, _ time.Duration) error {
+	return nil
+}
+
+func (m *fakeManager) WaitForCleanup(_ context.Context, _ string, _ secretsmanager.ConsumersMigratedFunc) (bool, error) {
+	return false, nil
+}
+
+func (m *fakeManager) List() []secretsmanager.Summary {
+	return nil
+}
+
+func (m *fakeManager) Expiring(_ time.Duration) []secretsmanager.ExpiringSecret {
+	return nil
+}
+
+func (m *fakeManager) NeedsRotation(_ time.Duration) []string {
+	return nil
+}
+
+func (m *fakeManager) Verify(_ context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func (m *fakeManager) Flush(_ context.Context) error {
+	return nil
+}
+
+func (m *fakeManager) Refresh(_ context.Context) error {
+	return nil
+}
+
+func (m *fakeManager) Report(_ context.Context) ([]secretsmanager.Status, error) {
+	return nil, nil
+}
+
+func (m *fakeManager) PruneBundles(_ context.Context, _ string, _ int) error {
+	return nil
+}
+
+func (m *fakeManager) Migrate(_ context.Context) error {
+	return nil
+}
+
+func (m *fakeManager) RotateCA(_ context.Context, _ string) ([]*corev1.Secret, error) {
+	return nil, nil
+}
+
+func (m *fakeManager) RotatePassword(_ context.Context, _ string, _ ...secretsmanager.GenerateOption) (*corev1.Secret, error) {
+	return nil, nil
+}
+
+func (m *fakeManager) GenerateCRL(_ context.Context, caName string, _ []secretsmanager.RevokedCert) (*corev1.Secret, error) {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      caName + "-crl",
+			Namespace: m.namespace,
+		},
+		Data: map[string][]byte{"crl": []byte("data-for-" + caName)},
+	}, nil
+}
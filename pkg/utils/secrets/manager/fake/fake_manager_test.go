@@ -68,9 +68,9 @@ var _ = Describe("FakeManager", func() {
 
 	Describe("#Generate", func() {
 		var (
-			config         = &secretutils.BasicAuthSecretConfig{Name: name, Format: secretutils.BasicAuthFormatNormal}
-			configChecksum = "17492942871593004096"
-			secretName     = name + "-fa646dad"
+			config         = &secretutils.BasicAuthSecretConfig{Name: name, Format: secretutils.BasicAuthFormatNormal, PasswordLength: 32}
+			configChecksum = "10837239309062535391"
+			secretName     = name + "-491e32c5"
 		)
 
 		It("should create a secret for the config", func() {
@@ -16,7 +16,14 @@ package manager
 
 import (
 	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"fmt"
+	"net"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,34 +34,61 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/util/clock"
 	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+	kubernetesretry "k8s.io/client-go/util/retry"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (m *manager) Generate(ctx context.Context, config secretutils.ConfigInterface, opts ...GenerateOption) (*corev1.Secret, error) {
+	nameLock := m.lockForName(config.GetName())
+	nameLock.Lock()
+	defer nameLock.Unlock()
+
 	options := &GenerateOptions{}
 	if err := options.ApplyOptions(m, config, opts); err != nil {
 		return nil, err
 	}
 
+	if err := validateNoConflictingDataKeyOptions(options); err != nil {
+		return nil, err
+	}
+
+	namespace := m.namespace
+	if options.namespace != "" {
+		namespace = options.namespace
+	}
+
+	if options.resolveSigningCA != nil {
+		if err := options.resolveSigningCA(ctx, namespace); err != nil {
+			return nil, err
+		}
+	}
+
 	var bundleFor *string
 	if options.isBundleSecret {
 		bundleFor = pointer.String(strings.TrimSuffix(config.GetName(), nameSuffixBundle))
 	}
 
+	validityClock := m.clock
+	if options.clock != nil {
+		validityClock = options.clock
+	}
+
 	var validUntilTime *string
 	if options.Validity > 0 {
-		validUntilTime = pointer.String(unixTime(m.clock.Now().Add(options.Validity)))
+		validUntilTime = pointer.String(unixTime(validityClock.Now().Add(options.Validity)))
 	}
 
 	objectMeta, err := ObjectMeta(
-		m.namespace,
+		namespace,
 		m.identity,
 		config,
 		options.IgnoreConfigChecksumForCASecretName,
@@ -63,66 +97,229 @@ func (m *manager) Generate(ctx context.Context, config secretutils.ConfigInterfa
 		options.signingCAChecksum,
 		&options.Persist,
 		bundleFor,
+		m.namePrefix,
+		m.nameChecksumFunc,
 	)
 	if err != nil {
 		return nil, err
 	}
+	if options.name != "" {
+		objectMeta.Name = m.namePrefix + options.name
+	}
 	desiredLabels := utils.MergeStringMaps(objectMeta.Labels) // copy labels map
 
+	var caBundlePEM []byte
+	if options.includeCABundle {
+		caBundlePEM, err = m.caBundlePEMForIncludeCABundle(namespace, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	secret := &corev1.Secret{}
 	if err := m.client.Get(ctx, kutil.Key(objectMeta.Namespace, objectMeta.Name), secret); err != nil {
 		if !apierrors.IsNotFound(err) {
 			return nil, err
 		}
 
-		secret, err = m.generateAndCreate(ctx, config, objectMeta)
-		if err != nil {
-			return nil, err
+		adopted := false
+		if options.isBundleSecret && options.adoptBundleFromOtherIdentity {
+			foreignSecret, err := m.findBundleSecretFromOtherIdentity(ctx, namespace, *bundleFor)
+			if err != nil {
+				return nil, err
+			}
+			if foreignSecret != nil {
+				secret = foreignSecret
+				adopted = true
+				m.logDecision(config.GetName(), "adopted", "", string(options.RotationStrategy))
+			}
+		}
+
+		if !adopted {
+			reason, err := m.rotationReason(ctx, namespace, config, objectMeta.Labels)
+			if err != nil {
+				return nil, err
+			}
+
+			if options.onRotation != nil {
+				options.onRotation(reason)
+			}
+
+			secret, err = m.generateAndCreate(ctx, namespace, config, objectMeta, options.clock, options.tlsSecretType, options.mutable, options.publicOnly, options.secretType, caBundlePEM, options.adoptExisting)
+			if err != nil {
+				return nil, err
+			}
+
+			m.logDecision(config.GetName(), "created", string(reason), string(options.RotationStrategy))
+			m.setLastRotationReason(config.GetName(), reason)
+		}
+	} else {
+		if options.failOnExisting && secret.Labels[LabelKeyManagerIdentity] != m.identity {
+			return nil, fmt.Errorf("refusing to adopt existing secret %q since it is not managed by this secrets manager (identity %q), and FailOnExisting is set", client.ObjectKeyFromObject(secret), m.identity)
+		}
+
+		// With a pinned name (WithName), a config or signing CA change can no longer be detected by the name itself
+		// differing from the existing secret's name (as it normally would), so it has to be detected by comparing
+		// checksums instead, and treated like a ForceRegenerate.
+		pinnedNameConfigChanged := options.name != "" &&
+			(secret.Labels[LabelKeyChecksumConfig] != objectMeta.Labels[LabelKeyChecksumConfig] ||
+				secret.Labels[LabelKeyChecksumSigningCA] != objectMeta.Labels[LabelKeyChecksumSigningCA])
+
+		if options.forceRegenerate || pinnedNameConfigChanged {
+			reason := RotationReasonForceRegenerate
+			action := "force-regenerated"
+			if pinnedNameConfigChanged {
+				reason = RotationReasonConfigChange
+				action = "config-changed"
+			}
+
+			var compromisedSecret *corev1.Secret
+			if options.RotationStrategy == KeepOld && !options.IgnoreOldSecrets {
+				compromisedSecret = secret.DeepCopy()
+			}
+
+			if options.onRotation != nil {
+				options.onRotation(reason)
+			}
+
+			secret, err = m.generateAndUpdate(ctx, namespace, config, secret, options.clock, options.tlsSecretType, options.mutable, options.publicOnly, options.secretType, caBundlePEM, options.adoptExisting)
+			if err != nil {
+				return nil, err
+			}
+
+			m.logDecision(config.GetName(), action, string(reason), string(options.RotationStrategy))
+			m.setLastRotationReason(config.GetName(), reason)
+
+			if compromisedSecret != nil {
+				if err := m.addToStore(namespace, config.GetName(), compromisedSecret, old); err != nil {
+					return nil, err
+				}
+			}
+		} else {
+			m.logDecision(config.GetName(), "kept", "", string(options.RotationStrategy))
 		}
 	}
 
 	if !options.isBundleSecret {
-		if err := m.addToStore(config.GetName(), secret, current); err != nil {
+		if err := m.addToStore(namespace, config.GetName(), secret, current); err != nil {
 			return nil, err
 		}
 
-		if !options.IgnoreOldSecrets && options.RotationStrategy == KeepOld {
-			if err := m.storeOldSecrets(ctx, config.GetName(), secret.Name); err != nil {
+		if maxOld := options.RotationStrategy.keepOldSecretsCount(); !options.IgnoreOldSecrets && maxOld > 0 {
+			if err := m.storeOldSecrets(ctx, namespace, config.GetName(), secret.Name, maxOld, options.ownerReference); err != nil {
 				return nil, err
 			}
+
+			if secrets, found := m.getFromStore(namespace, config.GetName()); found && secrets.old != nil {
+				desiredLabels[LabelKeyRotationPhase] = LabelValueRotationPhasePreparing
+			}
 		}
 
-		if err := m.generateBundleSecret(ctx, config); err != nil {
+		if err := m.generateBundleSecret(ctx, namespace, config, options.adoptBundleFromOtherIdentity, options.maintainTrustBundleConfigMap, options.includeSystemTrustStoreKey, options.failOnExisting, options.ownerReference); err != nil {
 			return nil, err
 		}
 	}
 
-	if err := m.maintainLifetimeLabels(config, secret, desiredLabels); err != nil {
+	desiredAnnotations := utils.MergeStringMaps(map[string]string{}, secret.Annotations) // copy annotations map
+	desiredAnnotations = utils.MergeStringMaps(desiredAnnotations, options.annotations)
+	desiredAnnotations[AnnotationKeyDataChecksum] = utils.ComputeSecretChecksum(secret.Data)
+
+	if options.signingCAChecksum != nil {
+		desiredAnnotations[AnnotationKeySignedByCA] = *options.signingCAName
+		desiredAnnotations[AnnotationKeySignedByCAChecksum] = *options.signingCAChecksum
+	}
+
+	if err := m.maintainLifetimeLabels(config, secret, desiredLabels, desiredAnnotations, options.renewalWindow, options.clock); err != nil {
 		return nil, err
 	}
 
-	if err := m.reconcileSecret(ctx, secret, desiredLabels); err != nil {
+	if err := m.reconcileSecret(ctx, secret, desiredLabels, desiredAnnotations, options.ownerReference, options.mutable, string(options.RotationStrategy)); err != nil {
 		return nil, err
 	}
 
+	if !options.isBundleSecret {
+		m.registerConfig(config, opts, options.signedByInternalCA, options.signingCAName)
+
+		if options.maintainStableNameAlias {
+			if err := m.maintainStableNameAliasSecret(ctx, namespace, config.GetName(), secret, options.ownerReference); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return secret, nil
 }
 
-func (m *manager) generateAndCreate(ctx context.Context, config secretutils.ConfigInterface, objectMeta metav1.ObjectMeta) (*corev1.Secret, error) {
-	data, err := config.Generate()
+// logDecision emits a structured, V(1) (debug) log line describing a decision Generate made for the secret with the
+// given config name, e.g. whether it created a new secret, kept an existing one, or adopted a legacy one. reason and
+// rotationStrategy may be passed as empty strings where not applicable. It never logs any secret material.
+func (m *manager) logDecision(name, action, reason, rotationStrategy string) {
+	m.logger.V(1).Info("Secret generation decision", "name", name, "action", action, "reason", reason, "rotationStrategy", rotationStrategy)
+}
+
+// ComputeSecretName returns the name a Generate call with the given configuration and options would produce, without
+// creating or otherwise mutating any secret. This allows consumers (e.g. admission webhooks) to predict the name of
+// a secret before it exists. It accounts for SignedByCA (by resolving the referenced CA's checksum) and
+// IgnoreConfigChecksumForCASecretName, mirroring the name computation performed by Generate.
+func (m *manager) ComputeSecretName(config secretutils.ConfigInterface, opts ...GenerateOption) (string, error) {
+	options := &GenerateOptions{}
+	if err := options.ApplyOptions(m, config, opts); err != nil {
+		return "", err
+	}
+
+	namespace := m.namespace
+	if options.namespace != "" {
+		namespace = options.namespace
+	}
+
+	if options.resolveSigningCA != nil {
+		// ComputeSecretName has no context.Context parameter of its own; a background context is only ever
+		// exercised by SignedByExternalCA, which needs to look up the referenced CA secret to compute its checksum.
+		if err := options.resolveSigningCA(context.Background(), namespace); err != nil {
+			return "", err
+		}
+	}
+
+	objectMeta, err := ObjectMeta(
+		namespace,
+		m.identity,
+		config,
+		options.IgnoreConfigChecksumForCASecretName,
+		m.lastRotationInitiationTimes[config.GetName()],
+		nil,
+		options.signingCAChecksum,
+		nil,
+		nil,
+		m.namePrefix,
+		m.nameChecksumFunc,
+	)
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+	if options.name != "" {
+		return m.namePrefix + options.name, nil
 	}
 
-	// For backwards-compatibility, we need to keep some of the existing secrets (cluster-admin token, basic auth
-	// password, etc.).
-	// TODO(rfranzke): Remove this code in the future
-	dataMap, err := m.keepExistingSecretsIfNeeded(ctx, config.GetName(), data.SecretData())
+	return objectMeta.Name, nil
+}
+
+func (m *manager) generateAndCreate(ctx context.Context, namespace string, config secretutils.ConfigInterface, objectMeta metav1.ObjectMeta, clockOverride clock.Clock, tlsSecretType, mutable, publicOnly bool, secretType *corev1.SecretType, caBundlePEM []byte, adoptExisting *[]string) (*corev1.Secret, error) {
+	dataMap, err := m.generateSecretData(ctx, namespace, config, clockOverride, tlsSecretType, publicOnly, caBundlePEM, adoptExisting)
 	if err != nil {
 		return nil, err
 	}
 
 	secret := Secret(objectMeta, dataMap)
+	if secretType != nil {
+		if err := validateSecretType(*secretType, dataMap); err != nil {
+			return nil, err
+		}
+		secret.Type = *secretType
+	}
+	if mutable {
+		secret.Immutable = nil
+	}
+
 	if err := m.client.Create(ctx, secret); err != nil {
 		if !apierrors.IsAlreadyExists(err) {
 			return nil, err
@@ -137,7 +334,313 @@ func (m *manager) generateAndCreate(ctx context.Context, config secretutils.Conf
 	return secret, nil
 }
 
-func (m *manager) keepExistingSecretsIfNeeded(ctx context.Context, configName string, newData map[string][]byte) (map[string][]byte, error) {
+// generateAndUpdate regenerates fresh secret material for the given config and overwrites the data of the already
+// existing secret in place, keeping its name (and hence its checksum-derived labels) unchanged. It is used by the
+// ForceRegenerate option, which needs to mint new material even though nothing that would normally cause the
+// computed secret name to change (config, signing CA, rotation time) has actually changed. It is also used for a secret
+// with a pinned name (WithName) whose config or signing CA checksum changed, since its name cannot change either.
+func (m *manager) generateAndUpdate(ctx context.Context, namespace string, config secretutils.ConfigInterface, existing *corev1.Secret, clockOverride clock.Clock, tlsSecretType, mutable, publicOnly bool, secretType *corev1.SecretType, caBundlePEM []byte, adoptExisting *[]string) (*corev1.Secret, error) {
+	dataMap, err := m.generateSecretData(ctx, namespace, config, clockOverride, tlsSecretType, publicOnly, caBundlePEM, adoptExisting)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := existing.DeepCopy()
+	secret.Data = dataMap
+	if secretType != nil {
+		if err := validateSecretType(*secretType, dataMap); err != nil {
+			return nil, err
+		}
+		secret.Type = *secretType
+	}
+	if mutable {
+		secret.Immutable = nil
+	} else {
+		secret.Immutable = pointer.Bool(true)
+	}
+
+	if err := m.client.Update(ctx, secret); err != nil {
+		return nil, err
+	}
+
+	m.logger.Info("Force-regenerated secret", "configName", config.GetName(), "secretName", secret.Name)
+	return secret, nil
+}
+
+// generateSecretData generates fresh secret data for the given config, applying the same backwards-compatibility and
+// secret-shape adjustments regardless of whether the result ends up in a newly created or an updated secret.
+func (m *manager) generateSecretData(ctx context.Context, namespace string, config secretutils.ConfigInterface, clockOverride clock.Clock, tlsSecretType, publicOnly bool, caBundlePEM []byte, adoptExisting *[]string) (map[string][]byte, error) {
+	if err := m.retainOldETCDEncryptionKeyIfNeeded(namespace, config); err != nil {
+		return nil, err
+	}
+
+	if err := m.retainOldBasicAuthPasswordIfNeeded(namespace, config); err != nil {
+		return nil, err
+	}
+
+	if err := m.retainOldServiceAccountKeyForJWKSIfNeeded(namespace, config); err != nil {
+		return nil, err
+	}
+
+	if caBundlePEM != nil {
+		if controlPlaneConfig, ok := config.(*secretutils.ControlPlaneSecretConfig); ok {
+			controlPlaneConfig.CABundlePEM = caBundlePEM
+		}
+	}
+
+	applyClockOverrideIfNeeded(config, clockOverride)
+	applySerialNumberFunc(config, m.serialNumberFunc)
+	m.logCACappedValidityIfNeeded(config, clockOverride)
+
+	data, err := config.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	// For backwards-compatibility, we need to keep some of the existing secrets (cluster-admin token, basic auth
+	// password, etc.).
+	// TODO(rfranzke): Remove this code in the future
+	dataMap, err := m.keepExistingSecretsIfNeeded(ctx, namespace, config.GetName(), data.SecretData(), adoptExisting)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsSecretType {
+		dataMap = withTLSSecretDataKeys(dataMap)
+	}
+
+	if publicOnly {
+		dataMap = withoutPrivateKeyDataKeys(dataMap)
+	}
+
+	if caBundlePEM != nil {
+		dataMap[secretutils.DataKeyCertificateCA] = caBundlePEM
+	}
+
+	return dataMap, nil
+}
+
+// logCACappedValidityIfNeeded logs when a leaf certificate's requested validity would extend beyond its signing CA's
+// 'NotAfter', since generateCertificateTemplate clamps the leaf's 'NotAfter' to the CA's in that case so that the
+// leaf does not outlive its issuer.
+func (m *manager) logCACappedValidityIfNeeded(config secretutils.ConfigInterface, clockOverride clock.Clock) {
+	certConfig, ok := config.(*secretutils.CertificateSecretConfig)
+	if !ok || certConfig.CertType == secretutils.CACert || certConfig.SigningCA == nil {
+		return
+	}
+
+	now := m.clock
+	if clockOverride != nil {
+		now = clockOverride
+	}
+
+	requestedNotAfter := now.Now().AddDate(10, 0, 0)
+	if certConfig.Validity != nil {
+		requestedNotAfter = now.Now().Add(*certConfig.Validity)
+	}
+
+	if certConfig.SigningCA.Certificate.NotAfter.Before(requestedNotAfter) {
+		m.logger.Info("Requested certificate validity would outlive its signing CA, clamping NotAfter to the CA's expiry", "name", config.GetName(), "caNotAfter", certConfig.SigningCA.Certificate.NotAfter, "requestedNotAfter", requestedNotAfter)
+	}
+}
+
+// applyClockOverrideIfNeeded sets the Clock field of config to clockOverride if a WithClock override was given for
+// this call and config is a type that carries its own Clock field, so that the certificate it generates uses the
+// overridden reference time for NotBefore/NotAfter instead of the config's own Clock (or, if that is unset, real
+// time) like it otherwise would.
+func applyClockOverrideIfNeeded(config secretutils.ConfigInterface, clockOverride clock.Clock) {
+	if clockOverride == nil {
+		return
+	}
+
+	switch cfg := config.(type) {
+	case *secretutils.CertificateSecretConfig:
+		cfg.Clock = clockOverride
+	case *secretutils.ControlPlaneSecretConfig:
+		if cfg.CertificateSecretConfig != nil {
+			cfg.CertificateSecretConfig.Clock = clockOverride
+		}
+	}
+}
+
+// applySerialNumberFunc sets the SerialNumberFunc field of config to serialNumberFunc if config is a type that
+// carries its own SerialNumberFunc field, so that the certificate it generates draws its serial number from the
+// manager's configured provider (DefaultSerialNumberFunc, unless swapped before the manager was constructed) instead
+// of whatever the config's own SerialNumberFunc is set to.
+func applySerialNumberFunc(config secretutils.ConfigInterface, serialNumberFunc SerialNumberFunc) {
+	switch cfg := config.(type) {
+	case *secretutils.CertificateSecretConfig:
+		cfg.SerialNumberFunc = serialNumberFunc
+	case *secretutils.ControlPlaneSecretConfig:
+		if cfg.CertificateSecretConfig != nil {
+			cfg.CertificateSecretConfig.SerialNumberFunc = serialNumberFunc
+		}
+	}
+}
+
+// withTLSSecretDataKeys returns a copy of data with the certificate and private key additionally stored under the
+// standard 'tls.crt'/'tls.key' keys, alongside whatever keys they were already stored under (e.g. 'ca.crt'/'ca.key'
+// for a CA secret). This causes secretTypeForData to classify the resulting secret as 'kubernetes.io/tls'.
+func withTLSSecretDataKeys(data map[string][]byte) map[string][]byte {
+	result := make(map[string][]byte, len(data)+2)
+	for k, v := range data {
+		result[k] = v
+	}
+
+	cert, key := result[secretutils.DataKeyCertificate], result[secretutils.DataKeyPrivateKey]
+	if cert == nil || key == nil {
+		cert, key = result[secretutils.DataKeyCertificateCA], result[secretutils.DataKeyPrivateKeyCA]
+	}
+
+	result[secretutils.DataKeyCertificate] = cert
+	result[secretutils.DataKeyPrivateKey] = key
+
+	return result
+}
+
+// withoutPrivateKeyDataKeys returns a copy of data with the private key data keys removed, so that only the
+// certificate/CA PEM data remains.
+func withoutPrivateKeyDataKeys(data map[string][]byte) map[string][]byte {
+	result := make(map[string][]byte, len(data))
+	for k, v := range data {
+		if k == secretutils.DataKeyPrivateKey || k == secretutils.DataKeyPrivateKeyCA {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// caBundlePEMForIncludeCABundle returns the PEM-encoded certificate bundle (current and, if present, old CA
+// certificate) currently tracked for the CA signing the given leaf certificate config, for embedding into the leaf
+// secret via the IncludeCABundle option. It requires the config to be a CertificateSecretConfig signed by a CA whose
+// bundle secret was already generated by this manager, e.g. via a prior Generate call for the CA itself.
+func (m *manager) caBundlePEMForIncludeCABundle(namespace string, config secretutils.ConfigInterface) ([]byte, error) {
+	certConfig := certificateSecretConfigForIncludeCABundle(config)
+	if certConfig == nil || certConfig.SigningCA == nil {
+		return nil, fmt.Errorf("IncludeCABundle can only be used for certificates signed by a CA (SignedByCA option)")
+	}
+
+	caSecrets, found := m.getFromStore(namespace, certConfig.SigningCA.Name)
+	if !found || caSecrets.bundle == nil {
+		return nil, fmt.Errorf("could not find CA bundle secret for %q to embed via IncludeCABundle", certConfig.SigningCA.Name)
+	}
+
+	return caSecrets.bundle.obj.Data[secretutils.DataKeyCertificateBundle], nil
+}
+
+// certificateSecretConfigForIncludeCABundle returns the CertificateSecretConfig whose signing CA should be consulted
+// for IncludeCABundle, unwrapping a ControlPlaneSecretConfig's embedded CertificateSecretConfig if necessary. It
+// returns nil if config is neither.
+func certificateSecretConfigForIncludeCABundle(config secretutils.ConfigInterface) *secretutils.CertificateSecretConfig {
+	switch c := config.(type) {
+	case *secretutils.CertificateSecretConfig:
+		return c
+	case *secretutils.ControlPlaneSecretConfig:
+		return c.CertificateSecretConfig
+	default:
+		return nil
+	}
+}
+
+// retainOldETCDEncryptionKeyIfNeeded prepares an ETCDEncryptionKeySecretConfig for zero-downtime key rotation: if a
+// secret is already tracked in the internal store under the same name (i.e., this call is regenerating the secret
+// rather than creating it for the first time), its key is carried over into the config's RetainedKeys so that the
+// freshly generated key ends up in front of it in the resulting secret data, allowing consumers to keep decrypting
+// data with the outgoing key until it has been rewritten with the new one.
+func (m *manager) retainOldETCDEncryptionKeyIfNeeded(namespace string, config secretutils.ConfigInterface) error {
+	cfg, ok := config.(*secretutils.ETCDEncryptionKeySecretConfig)
+	if !ok || len(cfg.RetainedKeys) != 0 {
+		return nil
+	}
+
+	secrets, found := m.getFromStore(namespace, config.GetName())
+	if !found {
+		return nil
+	}
+
+	retainedKeys, err := secretutils.LoadRetainedEncryptionKeysFromCSV(secrets.current.obj.Data[secretutils.DataKeyRetainedEncryptionKeysCSV])
+	if err != nil {
+		return err
+	}
+
+	cfg.RetainedKeys = append([]secretutils.ETCDEncryptionKeyEntry{{
+		Key:    string(secrets.current.obj.Data[secretutils.DataKeyEncryptionKeyName]),
+		Secret: string(secrets.current.obj.Data[secretutils.DataKeyEncryptionSecret]),
+	}}, retainedKeys...)
+
+	return nil
+}
+
+// retainOldBasicAuthPasswordIfNeeded carries the password of the previous secret for the given BasicAuthSecretConfig
+// (if any) forward into its OldPassword field, so that the newly generated secret's 'auth'/CSV data authenticates
+// both the old and the new password during a rotation grace period (e.g. when rotating with Rotate(KeepOld)).
+func (m *manager) retainOldBasicAuthPasswordIfNeeded(namespace string, config secretutils.ConfigInterface) error {
+	cfg, ok := config.(*secretutils.BasicAuthSecretConfig)
+	if !ok || cfg.OldPassword != "" {
+		return nil
+	}
+
+	secrets, found := m.getFromStore(namespace, config.GetName())
+	if !found {
+		return nil
+	}
+
+	if password, ok := secrets.current.obj.Data[secretutils.DataKeyPassword]; ok {
+		cfg.OldPassword = string(password)
+		return nil
+	}
+
+	existingBasicAuth, err := secretutils.LoadBasicAuthFromCSV("", secrets.current.obj.Data[secretutils.DataKeyCSV])
+	if err != nil {
+		return err
+	}
+	cfg.OldPassword = existingBasicAuth.Password
+
+	return nil
+}
+
+// retainOldServiceAccountKeyForJWKSIfNeeded carries the public key of the previous secret for the given
+// RSASecretConfig (if any) forward into its RetainedPublicKeys field, so that the newly generated secret's JWKS
+// document (see RSASecretConfig.IncludeJWKS) still validates tokens signed with the outgoing key during a rotation
+// grace period (e.g. when rotating with Rotate(KeepOld)).
+func (m *manager) retainOldServiceAccountKeyForJWKSIfNeeded(namespace string, config secretutils.ConfigInterface) error {
+	cfg, ok := config.(*secretutils.RSASecretConfig)
+	if !ok || !cfg.IncludeJWKS || len(cfg.RetainedPublicKeys) != 0 {
+		return nil
+	}
+
+	secrets, found := m.getFromStore(namespace, config.GetName())
+	if !found {
+		return nil
+	}
+
+	privateKeyDataKey := cfg.PrivateKeyDataKey
+	if privateKeyDataKey == "" {
+		privateKeyDataKey = secretutils.DataKeyRSAPrivateKey
+	}
+
+	privateKey, err := utils.DecodePrivateKey(secrets.current.obj.Data[privateKeyDataKey])
+	if err != nil {
+		return err
+	}
+
+	cfg.RetainedPublicKeys = []*rsa.PublicKey{&privateKey.PublicKey}
+	return nil
+}
+
+// keepExistingSecretsIfNeeded implements the implicit, by-name adoption of a fixed set of well-known legacy secrets
+// (created before the secrets manager existed) into their manager-managed successor, for the legacy names listed in
+// the switch below. During the deprecation period, it is only gated by the AdoptExisting option if that option was
+// passed at all; callers that do not yet know about it keep today's implicit behavior unchanged. Once AdoptExisting
+// has been adopted everywhere, this implicit fallback should be removed and adoption should always require the
+// config's name to be listed in a caller-provided AdoptExisting(...) call.
+// TODO(rfranzke): Require AdoptExisting unconditionally once all callers have migrated to passing it explicitly.
+func (m *manager) keepExistingSecretsIfNeeded(ctx context.Context, namespace, configName string, newData map[string][]byte, adoptExisting *[]string) (map[string][]byte, error) {
+	if adoptExisting != nil && !utils.ValueExists(configName, *adoptExisting) {
+		return newData, nil
+	}
+
 	existingSecret := &corev1.Secret{}
 
 	switch configName {
@@ -145,7 +648,7 @@ func (m *manager) keepExistingSecretsIfNeeded(ctx context.Context, configName st
 		// TODO(rfranzke): Drop this code before promoting the ShootCARotation feature gate to beta. Otherwise, the
 		//  cluster CA will still be used as client CA during the first shoot CA certificate rotation since the `ca`
 		//  secret will still exist. This code is only very temporary to ensure all shoots get a `ca-client` secret.
-		if err := m.client.Get(ctx, kutil.Key(m.namespace, "ca"), existingSecret); err != nil {
+		if err := m.client.Get(ctx, kutil.Key(namespace, "ca"), existingSecret); err != nil {
 			if !apierrors.IsNotFound(err) {
 				return nil, err
 			}
@@ -161,7 +664,7 @@ func (m *manager) keepExistingSecretsIfNeeded(ctx context.Context, configName st
 			oldSecretName = "monitoring-ingress-credentials-users"
 		}
 
-		if err := m.client.Get(ctx, kutil.Key(m.namespace, oldSecretName), existingSecret); err != nil {
+		if err := m.client.Get(ctx, kutil.Key(namespace, oldSecretName), existingSecret); err != nil {
 			if !apierrors.IsNotFound(err) {
 				return nil, err
 			}
@@ -190,7 +693,7 @@ func (m *manager) keepExistingSecretsIfNeeded(ctx context.Context, configName st
 		return newBasicAuth.SecretData(), nil
 
 	case "kube-apiserver-static-token":
-		if err := m.client.Get(ctx, kutil.Key(m.namespace, "static-token"), existingSecret); err != nil {
+		if err := m.client.Get(ctx, kutil.Key(namespace, "static-token"), existingSecret); err != nil {
 			if !apierrors.IsNotFound(err) {
 				return nil, err
 			}
@@ -217,60 +720,36 @@ func (m *manager) keepExistingSecretsIfNeeded(ctx context.Context, configName st
 		return newStaticToken.SecretData(), nil
 
 	case "ssh-keypair":
-		if err := m.client.Get(ctx, kutil.Key(m.namespace, "ssh-keypair"), existingSecret); err != nil {
+		if err := m.client.Get(ctx, kutil.Key(namespace, "ssh-keypair"), existingSecret); err != nil {
 			if !apierrors.IsNotFound(err) {
 				return nil, err
 			}
 			return newData, nil
 		}
 
+		// The primary legacy secret's data is adopted into a new, manager-managed secret below, but the legacy
+		// object itself would otherwise be left behind as an orphaned, unmanaged secret forever. Relabel and
+		// immutabilize it so that it is picked up as a tracked "old" secret instead (e.g. by storeOldSecrets, if the
+		// caller requested the KeepOld rotation strategy) and swept by Cleanup once it is no longer needed.
+		if err := m.relabelAndImmutabilizeLegacySecret(ctx, existingSecret, configName); err != nil {
+			return nil, err
+		}
+
 		// Before returning the existing data, check whether there is an ssh-keypair.old secret and label it so that it
 		// will be picked up by the `m.storeOldSecrets` function call.
 		existingSecretOld := &corev1.Secret{}
-		if err := m.client.Get(ctx, kutil.Key(m.namespace, "ssh-keypair.old"), existingSecretOld); err != nil {
+		if err := m.client.Get(ctx, kutil.Key(namespace, "ssh-keypair.old"), existingSecretOld); err != nil {
 			if !apierrors.IsNotFound(err) {
 				return nil, err
 			}
-		} else {
-			patch := client.MergeFrom(existingSecretOld.DeepCopy())
-			metav1.SetMetaDataLabel(&existingSecretOld.ObjectMeta, LabelKeyName, configName)
-			metav1.SetMetaDataLabel(&existingSecretOld.ObjectMeta, LabelKeyManagedBy, LabelValueSecretsManager)
-			metav1.SetMetaDataLabel(&existingSecretOld.ObjectMeta, LabelKeyManagerIdentity, m.identity)
-			metav1.SetMetaDataLabel(&existingSecretOld.ObjectMeta, LabelKeyPersist, LabelValueTrue)
-			metav1.SetMetaDataLabel(&existingSecretOld.ObjectMeta, LabelKeyLastRotationInitiationTime, "")
-			existingSecretOld.Immutable = pointer.Bool(true)
-			if err := m.client.Patch(ctx, existingSecretOld, patch); err != nil {
-				return nil, err
-			}
-
-			// Wait until cache reflects changes to prevent losing the old secret.
-			timeoutCtx, cancel := context.WithTimeout(ctx, time.Minute)
-			defer cancel()
-
-			if err := retry.Until(timeoutCtx, time.Second, func(ctx context.Context) (done bool, err error) {
-				secretList := &corev1.SecretList{}
-				if err := m.client.List(ctx, secretList, client.InNamespace(m.namespace), client.MatchingLabels{
-					LabelKeyName:            configName,
-					LabelKeyManagedBy:       LabelValueSecretsManager,
-					LabelKeyManagerIdentity: m.identity,
-				}); err != nil {
-					return retry.SevereError(err)
-				}
-
-				if len(secretList.Items) == 0 {
-					return retry.MinorError(fmt.Errorf("cache does not yet reflect the labeled ssh-keypair.old secret"))
-				}
-
-				return retry.Ok()
-			}); err != nil {
-				return nil, err
-			}
+		} else if err := m.relabelAndImmutabilizeLegacySecret(ctx, existingSecretOld, configName); err != nil {
+			return nil, err
 		}
 
 		return existingSecret.Data, nil
 
 	case "kube-apiserver-etcd-encryption-key":
-		if err := m.client.Get(ctx, kutil.Key(m.namespace, "etcd-encryption-secret"), existingSecret); err != nil {
+		if err := m.client.Get(ctx, kutil.Key(namespace, "etcd-encryption-secret"), existingSecret); err != nil {
 			if !apierrors.IsNotFound(err) {
 				return nil, err
 			}
@@ -313,7 +792,7 @@ func (m *manager) keepExistingSecretsIfNeeded(ctx context.Context, configName st
 		}, nil
 
 	case "service-account-key":
-		if err := m.client.Get(ctx, kutil.Key(m.namespace, "service-account-key"), existingSecret); err != nil {
+		if err := m.client.Get(ctx, kutil.Key(namespace, "service-account-key"), existingSecret); err != nil {
 			if !apierrors.IsNotFound(err) {
 				return nil, err
 			}
@@ -326,9 +805,57 @@ func (m *manager) keepExistingSecretsIfNeeded(ctx context.Context, configName st
 	return newData, nil
 }
 
-func (m *manager) storeOldSecrets(ctx context.Context, name, currentSecretName string) error {
+// relabelAndImmutabilizeLegacySecret patches the given pre-existing, unmanaged secret (created before the secrets
+// manager was introduced, or otherwise not carrying its labels) with the standard managed-by/identity/persist labels
+// and marks it immutable, then waits until the client's cache reflects the change. This allows it to be picked up as
+// a tracked secret (e.g. by storeOldSecrets) instead of leaking as an orphaned, unmanaged object once its data has
+// been adopted into a new, manager-managed secret.
+func (m *manager) relabelAndImmutabilizeLegacySecret(ctx context.Context, secret *corev1.Secret, configName string) error {
+	patch := client.MergeFrom(secret.DeepCopy())
+	metav1.SetMetaDataLabel(&secret.ObjectMeta, LabelKeyName, configName)
+	metav1.SetMetaDataLabel(&secret.ObjectMeta, LabelKeyManagedBy, LabelValueSecretsManager)
+	metav1.SetMetaDataLabel(&secret.ObjectMeta, LabelKeyManagerIdentity, m.identity)
+	metav1.SetMetaDataLabel(&secret.ObjectMeta, LabelKeyPersist, LabelValueTrue)
+	metav1.SetMetaDataLabel(&secret.ObjectMeta, LabelKeyLastRotationInitiationTime, "")
+	secret.Immutable = pointer.Bool(true)
+	if err := m.client.Patch(ctx, secret, patch); err != nil {
+		return err
+	}
+
+	// Wait until cache reflects changes to prevent losing the secret.
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	secretName := secret.Name
+	return retry.Until(timeoutCtx, time.Second, func(ctx context.Context) (done bool, err error) {
+		secretList := &corev1.SecretList{}
+		if err := m.client.List(ctx, secretList, client.InNamespace(secret.Namespace), client.MatchingLabels{
+			LabelKeyName:            configName,
+			LabelKeyManagedBy:       LabelValueSecretsManager,
+			LabelKeyManagerIdentity: m.identity,
+		}); err != nil {
+			return retry.SevereError(err)
+		}
+
+		for _, s := range secretList.Items {
+			if s.Name == secretName {
+				return retry.Ok()
+			}
+		}
+
+		return retry.MinorError(fmt.Errorf("cache does not yet reflect the labeled %q secret", secretName))
+	})
+}
+
+// storeOldSecrets lists every secret previously generated for the given name (other than the one just generated as
+// currentSecretName), and tracks the newest maxOld of them as the old secrets for that name: the single newest one
+// as the 'old' class (as consumed by CompleteRotation, WaitForCleanup and Cleanup), and, if maxOld > 1 (i.e. a
+// KeepLastN rotation strategy), all of the retained ones (accessible via GetRetained). Any further, older secrets
+// beyond that bound are deleted, since KeepLastN rotation strategies are responsible for pruning themselves instead
+// of relying on CompleteRotation/WaitForCleanup.
+func (m *manager) storeOldSecrets(ctx context.Context, namespace, name, currentSecretName string, maxOld int, ownerReference *metav1.OwnerReference) error {
 	secretList := &corev1.SecretList{}
-	if err := m.client.List(ctx, secretList, client.InNamespace(m.namespace), client.MatchingLabels{
+	if err := m.client.List(ctx, secretList, client.InNamespace(namespace), client.MatchingLabels{
 		LabelKeyName:            name,
 		LabelKeyManagedBy:       LabelValueSecretsManager,
 		LabelKeyManagerIdentity: m.identity,
@@ -336,29 +863,112 @@ func (m *manager) storeOldSecrets(ctx context.Context, name, currentSecretName s
 		return err
 	}
 
-	var oldSecret *corev1.Secret
+	var oldSecrets []*corev1.Secret
+	for i := range secretList.Items {
+		if secretList.Items[i].Name == currentSecretName {
+			continue
+		}
+		oldSecrets = append(oldSecrets, secretList.Items[i].DeepCopy())
+	}
+
+	if len(oldSecrets) == 0 {
+		return nil
+	}
 
-	for _, secret := range secretList.Items {
-		if secret.Name == currentSecretName {
+	sort.Slice(oldSecrets, func(i, j int) bool {
+		return issuedAtUnix(*oldSecrets[i]) > issuedAtUnix(*oldSecrets[j])
+	})
+
+	m.logDecision(name, "rotated-keep-old", "", string(KeepOld))
+
+	var retained []*corev1.Secret
+	for i, oldSecret := range oldSecrets {
+		if i >= maxOld {
+			m.logger.Info("Deleting old secret beyond the retained limit", "namespace", oldSecret.Namespace, "name", oldSecret.Name, "limit", maxOld)
+			if err := client.IgnoreNotFound(m.client.Delete(ctx, oldSecret)); err != nil {
+				return err
+			}
 			continue
 		}
 
-		if oldSecret == nil || oldSecret.CreationTimestamp.Time.Before(secret.CreationTimestamp.Time) {
-			oldSecret = secret.DeepCopy()
+		if ownerReference != nil {
+			patch := client.MergeFrom(oldSecret.DeepCopy())
+			oldSecret.OwnerReferences = kutil.MergeOwnerReferences(oldSecret.OwnerReferences, *ownerReference)
+			if err := m.client.Patch(ctx, oldSecret, patch); err != nil {
+				return err
+			}
+		}
+
+		retained = append(retained, oldSecret)
+	}
+
+	if err := m.addToStore(namespace, name, retained[0], old); err != nil {
+		return err
+	}
+
+	if maxOld > 1 {
+		return m.addRetainedToStore(namespace, name, retained)
+	}
+
+	return nil
+}
+
+// rotationReason determines why Generate is about to create a new secret for the given config instead of reusing an
+// existing one. If no secret exists at all for the config name yet, it's the first generation. Otherwise, if an
+// existing secret already carries the same config and signing CA checksums (i.e. only the last-rotation-initiation-
+// time differs), the new secret is the result of a triggered rotation. In any other case, the configuration itself
+// changed.
+func (m *manager) rotationReason(ctx context.Context, namespace string, config secretutils.ConfigInterface, desiredLabels map[string]string) (RotationReason, error) {
+	secretList := &corev1.SecretList{}
+	if err := m.client.List(ctx, secretList, client.InNamespace(namespace), client.MatchingLabels{
+		LabelKeyName:            config.GetName(),
+		LabelKeyManagedBy:       LabelValueSecretsManager,
+		LabelKeyManagerIdentity: m.identity,
+	}); err != nil {
+		return "", err
+	}
+
+	if len(secretList.Items) == 0 {
+		return RotationReasonFirstGeneration, nil
+	}
+
+	for _, secret := range secretList.Items {
+		if secret.Labels[LabelKeyChecksumConfig] == desiredLabels[LabelKeyChecksumConfig] &&
+			secret.Labels[LabelKeyChecksumSigningCA] == desiredLabels[LabelKeyChecksumSigningCA] {
+			return RotationReasonRotationTriggered, nil
 		}
 	}
 
-	if oldSecret == nil {
-		return nil
+	return RotationReasonConfigChange, nil
+}
+
+// findBundleSecretFromOtherIdentity looks up an existing bundle secret for the given name regardless of which
+// manager identity created it. It is used to adopt bundles produced by another identity instead of generating a
+// brand-new one, e.g. when migrating between manager identities. If multiple candidates exist, the newest one (by
+// creation timestamp) is returned.
+func (m *manager) findBundleSecretFromOtherIdentity(ctx context.Context, namespace, bundleFor string) (*corev1.Secret, error) {
+	secretList := &corev1.SecretList{}
+	if err := m.client.List(ctx, secretList, client.InNamespace(namespace), client.MatchingLabels{
+		LabelKeyBundleFor: bundleFor,
+		LabelKeyManagedBy: LabelValueSecretsManager,
+	}); err != nil {
+		return nil, err
 	}
 
-	return m.addToStore(oldSecret.Labels[LabelKeyName], oldSecret, old)
+	var newest *corev1.Secret
+	for i, secret := range secretList.Items {
+		if newest == nil || newest.CreationTimestamp.Time.Before(secret.CreationTimestamp.Time) {
+			newest = &secretList.Items[i]
+		}
+	}
+
+	return newest, nil
 }
 
-func (m *manager) generateBundleSecret(ctx context.Context, config secretutils.ConfigInterface) error {
+func (m *manager) generateBundleSecret(ctx context.Context, namespace string, config secretutils.ConfigInterface, adoptFromOtherIdentity, maintainTrustBundleConfigMap, includeSystemTrustStoreKey, failOnExisting bool, ownerReference *metav1.OwnerReference) error {
 	var bundleConfig secretutils.ConfigInterface
 
-	secrets, found := m.getFromStore(config.GetName())
+	secrets, found := m.getFromStore(namespace, config.GetName())
 	if !found {
 		return fmt.Errorf("secrets for name %q not found in internal store", config.GetName())
 	}
@@ -371,29 +981,124 @@ func (m *manager) generateBundleSecret(ctx context.Context, config secretutils.C
 				certs = append(certs, secrets.old.obj.Data[secretutils.DataKeyCertificateCA])
 			}
 
-			bundleConfig = &secretutils.CertificateBundleSecretConfig{
-				Name:            config.GetName() + nameSuffixBundle,
-				CertificatePEMs: certs,
-			}
+			bundleConfig = &secretutils.CertificateBundleSecretConfig{
+				Name:                       config.GetName() + nameSuffixBundle,
+				CertificatePEMs:            certs,
+				IncludeSystemTrustStoreKey: includeSystemTrustStoreKey,
+			}
+		}
+	}
+
+	if bundleConfig == nil {
+		return nil
+	}
+
+	bundleOpts := []GenerateOption{isBundleSecret()}
+	if adoptFromOtherIdentity {
+		bundleOpts = append(bundleOpts, AdoptBundleFromOtherIdentity())
+	}
+	if failOnExisting {
+		bundleOpts = append(bundleOpts, FailOnExisting())
+	}
+	if namespace != m.namespace {
+		bundleOpts = append(bundleOpts, InNamespace(namespace))
+	}
+	if ownerReference != nil {
+		bundleOpts = append(bundleOpts, WithOwnerReference(*ownerReference))
+	}
+
+	secret, err := m.Generate(ctx, bundleConfig, bundleOpts...)
+	if err != nil {
+		return err
+	}
+
+	if maintainTrustBundleConfigMap {
+		if err := m.maintainTrustBundleConfigMap(ctx, namespace, secret, ownerReference); err != nil {
+			return err
+		}
+	}
+
+	return m.addToStore(namespace, config.GetName(), secret, bundle)
+}
+
+// maintainTrustBundleConfigMap creates or updates a ConfigMap with the same name as the given bundle secret,
+// mirroring its certificate bundle PEM under the 'ca.crt' data key, so that in-cluster clients which expect trust
+// material as a ConfigMap don't need to read the bundle secret directly.
+func (m *manager) maintainTrustBundleConfigMap(ctx context.Context, namespace string, bundleSecret *corev1.Secret, ownerReference *metav1.OwnerReference) error {
+	bundleFor := bundleSecret.Labels[LabelKeyBundleFor]
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: bundleSecret.Name, Namespace: namespace}}
+
+	if err := m.client.Get(ctx, kutil.Key(namespace, configMap.Name), configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		configMap.Labels = map[string]string{LabelKeyTrustBundleFor: bundleFor}
+		configMap.Data = map[string]string{secretutils.DataKeyCertificateCA: string(bundleSecret.Data[secretutils.DataKeyCertificateBundle])}
+		if ownerReference != nil {
+			configMap.OwnerReferences = []metav1.OwnerReference{*ownerReference}
+		}
+
+		return m.client.Create(ctx, configMap)
+	}
+
+	patch := client.MergeFrom(configMap.DeepCopy())
+
+	metav1.SetMetaDataLabel(&configMap.ObjectMeta, LabelKeyTrustBundleFor, bundleFor)
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[secretutils.DataKeyCertificateCA] = string(bundleSecret.Data[secretutils.DataKeyCertificateBundle])
+	if ownerReference != nil {
+		configMap.OwnerReferences = kutil.MergeOwnerReferences(configMap.OwnerReferences, *ownerReference)
+	}
+
+	return m.client.Patch(ctx, configMap, patch)
+}
+
+// maintainStableNameAliasSecret creates or updates a mutable Secret named name (i.e. without the checksum/rotation
+// suffix the given, actually checksum-named current secret carries), mirroring its type and data under the
+// 'alias-for' label, for the WithStableNameAlias option.
+func (m *manager) maintainStableNameAliasSecret(ctx context.Context, namespace, name string, current *corev1.Secret, ownerReference *metav1.OwnerReference) error {
+	alias := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: m.namePrefix + name, Namespace: namespace}}
+
+	if err := m.client.Get(ctx, kutil.Key(namespace, alias.Name), alias); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
 		}
-	}
 
-	if bundleConfig == nil {
-		return nil
+		alias.Labels = map[string]string{LabelKeyAliasFor: name}
+		alias.Type = current.Type
+		alias.Data = current.Data
+		if ownerReference != nil {
+			alias.OwnerReferences = []metav1.OwnerReference{*ownerReference}
+		}
+
+		return m.client.Create(ctx, alias)
 	}
 
-	secret, err := m.Generate(ctx, bundleConfig, isBundleSecret())
-	if err != nil {
-		return err
+	patch := client.MergeFrom(alias.DeepCopy())
+
+	metav1.SetMetaDataLabel(&alias.ObjectMeta, LabelKeyAliasFor, name)
+	alias.Type = current.Type
+	alias.Data = current.Data
+	if ownerReference != nil {
+		alias.OwnerReferences = kutil.MergeOwnerReferences(alias.OwnerReferences, *ownerReference)
 	}
 
-	return m.addToStore(config.GetName(), secret, bundle)
+	return m.client.Patch(ctx, alias, patch)
 }
 
-func (m *manager) maintainLifetimeLabels(config secretutils.ConfigInterface, secret *corev1.Secret, desiredLabels map[string]string) error {
+func (m *manager) maintainLifetimeLabels(config secretutils.ConfigInterface, secret *corev1.Secret, desiredLabels, desiredAnnotations map[string]string, renewalWindow *time.Duration, clockOverride clock.Clock) error {
+	issuedAtClock := m.clock
+	if clockOverride != nil {
+		issuedAtClock = clockOverride
+	}
+
 	issuedAt := secret.Labels[LabelKeyIssuedAtTime]
 	if issuedAt == "" {
-		issuedAt = unixTime(m.clock.Now())
+		issuedAt = unixTime(issuedAtClock.Now())
 	}
 	desiredLabels[LabelKeyIssuedAtTime] = issuedAt
 
@@ -420,31 +1125,75 @@ func (m *manager) maintainLifetimeLabels(config secretutils.ConfigInterface, sec
 
 	desiredLabels[LabelKeyIssuedAtTime] = unixTime(certificate.NotBefore)
 	desiredLabels[LabelKeyValidUntilTime] = unixTime(certificate.NotAfter)
+
+	fingerprint := sha256.Sum256(certificate.Raw)
+	desiredAnnotations[AnnotationKeyCertificateFingerprintSHA256] = hex.EncodeToString(fingerprint[:])
+
+	if renewalWindow != nil {
+		desiredAnnotations[AnnotationKeyNextRenewalTime] = unixTime(certificate.NotAfter.Add(-*renewalWindow))
+	}
+
 	return nil
 }
 
-func (m *manager) reconcileSecret(ctx context.Context, secret *corev1.Secret, labels map[string]string) error {
-	patch := client.MergeFrom(secret.DeepCopy())
+// reconcileSecret patches the given secret so that it carries the desired labels, annotations, owner reference and
+// immutability setting. Since the in-memory secret may be stale by the time the patch is issued (e.g. because
+// another caller updated it concurrently), the patch is retried with a bounded backoff on conflict errors,
+// re-fetching the secret and recomputing the patch before each retry.
+func (m *manager) reconcileSecret(ctx context.Context, secret *corev1.Secret, labels, annotations map[string]string, ownerReference *metav1.OwnerReference, mutable bool, rotationStrategy string) error {
+	key := client.ObjectKeyFromObject(secret)
+	firstAttempt := true
+
+	return kubernetesretry.RetryOnConflict(kubernetesretry.DefaultBackoff, func() error {
+		if !firstAttempt {
+			if err := m.client.Get(ctx, key, secret); err != nil {
+				return err
+			}
+		}
+		firstAttempt = false
 
-	var mustPatch bool
+		patch := client.MergeFrom(secret.DeepCopy())
 
-	if secret.Immutable == nil || !*secret.Immutable {
-		secret.Immutable = pointer.Bool(true)
-		mustPatch = true
-	}
+		var mustPatch bool
 
-	for k, desired := range labels {
-		if current, ok := secret.Labels[k]; !ok || current != desired {
-			metav1.SetMetaDataLabel(&secret.ObjectMeta, k, desired)
+		if mutable {
+			if secret.Immutable != nil {
+				secret.Immutable = nil
+				mustPatch = true
+			}
+		} else if secret.Immutable == nil || !*secret.Immutable {
+			secret.Immutable = pointer.Bool(true)
 			mustPatch = true
 		}
-	}
 
-	if !mustPatch {
-		return nil
-	}
+		for k, desired := range labels {
+			if current, ok := secret.Labels[k]; !ok || current != desired {
+				metav1.SetMetaDataLabel(&secret.ObjectMeta, k, desired)
+				mustPatch = true
+			}
+		}
+
+		for k, desired := range annotations {
+			if current, ok := secret.Annotations[k]; !ok || current != desired {
+				metav1.SetMetaDataAnnotation(&secret.ObjectMeta, k, desired)
+				mustPatch = true
+			}
+		}
+
+		if ownerReference != nil {
+			if mergedReferences := kutil.MergeOwnerReferences(secret.OwnerReferences, *ownerReference); len(mergedReferences) != len(secret.OwnerReferences) {
+				secret.OwnerReferences = mergedReferences
+				mustPatch = true
+			}
+		}
+
+		if !mustPatch {
+			return nil
+		}
 
-	return m.client.Patch(ctx, secret, patch)
+		m.logDecision(labels[LabelKeyName], "reconciled", "", rotationStrategy)
+		return m.client.Patch(ctx, secret, patch)
+	})
 }
 
 // GenerateOption is some configuration that modifies options for a Generate request.
@@ -464,10 +1213,49 @@ type GenerateOptions struct {
 	// computing the secret name for CA secrets.
 	IgnoreConfigChecksumForCASecretName bool
 
-	signingCAChecksum *string
-	isBundleSecret    bool
+	namespace                    string
+	signingCAName                *string
+	signingCAChecksum            *string
+	signedByInternalCA           bool
+	isBundleSecret               bool
+	onRotation                   func(RotationReason)
+	adoptBundleFromOtherIdentity bool
+	tlsSecretType                bool
+	ownerReference               *metav1.OwnerReference
+	resolveSigningCA             func(ctx context.Context, namespace string) error
+	mutable                      bool
+	maintainTrustBundleConfigMap bool
+	publicOnly                   bool
+	includeCABundle              bool
+	failOnExisting               bool
+	forceRegenerate              bool
+	secretType                   *corev1.SecretType
+	name                         string
+	annotations                  map[string]string
+	renewalWindow                *time.Duration
+	adoptExisting                *[]string
+	clock                        clock.Clock
+	maintainStableNameAlias      bool
+	includeSystemTrustStoreKey   bool
 }
 
+// RotationReason describes why Generate decided to create a new secret instead of reusing the existing one.
+type RotationReason string
+
+const (
+	// RotationReasonFirstGeneration is used when no secret exists yet for the configured name.
+	RotationReasonFirstGeneration RotationReason = "FirstGeneration"
+	// RotationReasonConfigChange is used when the configuration (or its signing CA) used to generate the secret has
+	// changed compared to the newest existing secret.
+	RotationReasonConfigChange RotationReason = "ConfigChange"
+	// RotationReasonRotationTriggered is used when neither the configuration nor the signing CA changed, i.e. the
+	// last-rotation-initiation-time was updated to trigger the rotation.
+	RotationReasonRotationTriggered RotationReason = "RotationTriggered"
+	// RotationReasonForceRegenerate is used when the ForceRegenerate option made Generate mint fresh material for an
+	// existing secret even though neither the configuration, the signing CA, nor the rotation time changed.
+	RotationReasonForceRegenerate RotationReason = "ForceRegenerate"
+)
+
 type rotationStrategy string
 
 const (
@@ -475,8 +1263,35 @@ const (
 	InPlace rotationStrategy = "inplace"
 	// KeepOld is a constant for a rotation strategy regenerating a secret and keeping the old one in the system.
 	KeepOld rotationStrategy = "keepold"
+
+	keepLastNPrefix = "keeplastn-"
 )
 
+// KeepLastN is a rotation strategy regenerating a secret and keeping the last n previous secrets in the system, e.g.
+// for signing keys where tokens issued by several past keys may still be in flight. Unlike KeepOld, which keeps
+// exactly one previous secret, it keeps up to n of them (accessible via GetRetained), deleting any further ones once
+// they fall out of that bound.
+func KeepLastN(n int) rotationStrategy {
+	return rotationStrategy(fmt.Sprintf("%s%d", keepLastNPrefix, n))
+}
+
+// keepOldSecretsCount returns how many previous secrets this rotation strategy keeps: 0 for InPlace, 1 for KeepOld,
+// or n for KeepLastN(n).
+func (s rotationStrategy) keepOldSecretsCount() int {
+	switch {
+	case s == KeepOld:
+		return 1
+	case strings.HasPrefix(string(s), keepLastNPrefix):
+		n, err := strconv.Atoi(strings.TrimPrefix(string(s), keepLastNPrefix))
+		if err != nil || n <= 0 {
+			return 0
+		}
+		return n
+	default:
+		return 0
+	}
+}
+
 // ApplyOptions applies the given update options on these options, and then returns itself (for convenient chaining).
 func (o *GenerateOptions) ApplyOptions(manager Interface, configInterface secretutils.ConfigInterface, opts []GenerateOption) error {
 	for _, opt := range opts {
@@ -520,7 +1335,8 @@ func (useCurrentCAOption) ApplyToOptions(options *SignedByCAOptions) {
 
 // SignedByCA returns a function which sets the 'SigningCA' field in case the ConfigInterface provided to the
 // Generate request is a CertificateSecretConfig. Additionally, in such case it stores a checksum of the signing
-// CA in the options.
+// CA in the options. The actual signing CA is only resolved once all options (including Validity) have been
+// applied, since the choice between the current and the old CA can depend on the requested certificate validity.
 func SignedByCA(name string, opts ...SignedByCAOption) GenerateOption {
 	signedByCAOptions := &SignedByCAOptions{}
 	signedByCAOptions.ApplyOptions(opts)
@@ -541,25 +1357,137 @@ func SignedByCA(name string, opts ...SignedByCAOption) GenerateOption {
 			return fmt.Errorf("could not apply option to %T, expected *secrets.CertificateSecretConfig", config)
 		}
 
-		secrets, found := mgr.getFromStore(name)
-		if !found {
-			return fmt.Errorf("secrets for name %q not found in internal store", name)
+		options.resolveSigningCA = func(_ context.Context, namespace string) error {
+			return mgr.resolveSigningCA(namespace, name, certificateConfig, signedByCAOptions, options)
+		}
+		options.signedByInternalCA = true
+		return nil
+	}
+}
+
+// SignedByExternalCA returns a function which loads the CA certificate and key from the secret referenced by ref and
+// sets it as the 'SigningCA' field, in case the ConfigInterface provided to the Generate request is a
+// CertificateSecretConfig. Unlike SignedByCA, it does not require the CA to be tracked in this manager's internal
+// store, which allows leaf certificates to be signed by a CA that is generated and rotated by another controller
+// (potentially in a different namespace). Since the referenced secret is not tracked by this manager, it does not
+// benefit from the old/current CA selection performed by SignedByCA (e.g. UseCurrentCA has no effect); it always
+// uses whatever CA data the referenced secret currently holds.
+func SignedByExternalCA(ref corev1.SecretReference) GenerateOption {
+	return func(m Interface, config secretutils.ConfigInterface, options *GenerateOptions) error {
+		mgr, ok := m.(*manager)
+		if !ok {
+			return nil
+		}
+
+		var certificateConfig *secretutils.CertificateSecretConfig
+		switch cfg := config.(type) {
+		case *secretutils.CertificateSecretConfig:
+			certificateConfig = cfg
+		case *secretutils.ControlPlaneSecretConfig:
+			certificateConfig = cfg.CertificateSecretConfig
+		default:
+			return fmt.Errorf("could not apply option to %T, expected *secrets.CertificateSecretConfig", config)
 		}
 
-		// Client certificates are always renewed immediately (hence, signed with the current CA), while server
-		// certificates are signed with the old CA until they don't exist anymore in the internal store.
-		secret := secrets.current
-		if certificateConfig.CertType == secretutils.ServerCert && !signedByCAOptions.UseCurrentCA && secrets.old != nil {
-			secret = *secrets.old
+		options.resolveSigningCA = func(ctx context.Context, _ string) error {
+			return mgr.resolveExternalSigningCA(ctx, ref, certificateConfig, options)
 		}
+		return nil
+	}
+}
+
+// resolveExternalSigningCA loads the CA certificate and key from the secret referenced by ref and sets it on the
+// given certificateConfig, for use by SignedByExternalCA.
+func (m *manager) resolveExternalSigningCA(ctx context.Context, ref corev1.SecretReference, certificateConfig *secretutils.CertificateSecretConfig, options *GenerateOptions) error {
+	secret, err := kutil.GetSecretByReference(ctx, m.client, &ref)
+	if err != nil {
+		return fmt.Errorf("failed reading external CA secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+
+	if len(secret.Data[secretutils.DataKeyCertificateCA]) == 0 || len(secret.Data[secretutils.DataKeyPrivateKeyCA]) == 0 {
+		return fmt.Errorf("external CA secret %s/%s does not contain both %q and %q data keys", ref.Namespace, ref.Name, secretutils.DataKeyCertificateCA, secretutils.DataKeyPrivateKeyCA)
+	}
+
+	ca, err := secretutils.LoadCertificate(ref.Name, secret.Data[secretutils.DataKeyPrivateKeyCA], secret.Data[secretutils.DataKeyCertificateCA])
+	if err != nil {
+		return err
+	}
+
+	if err := validateIsCACertificate(ca); err != nil {
+		return fmt.Errorf("external CA secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+
+	certificateConfig.SigningCA = ca
+	options.signingCAName = pointer.String(ref.Name)
+	options.signingCAChecksum = pointer.String(kutil.TruncateLabelValue(utils.ComputeSecretChecksum(secret.Data)))
+	return nil
+}
+
+// validateIsCACertificate returns a descriptive error unless ca's certificate is actually usable to sign other
+// certificates, i.e. unless it has the 'IsCA' basic constraint and the 'certificate signing' key usage set. Without
+// this check, SignedByCA/SignedByExternalCA would silently accept e.g. a server or client certificate as a signer,
+// producing a leaf certificate that most TLS clients would refuse to validate against it.
+func validateIsCACertificate(ca *secretutils.Certificate) error {
+	if !ca.Certificate.IsCA {
+		return fmt.Errorf("certificate %q is not a CA certificate (IsCA is false)", ca.Name)
+	}
+	if ca.Certificate.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return fmt.Errorf("certificate %q cannot be used to sign other certificates (missing the 'certificate signing' key usage)", ca.Name)
+	}
+	return nil
+}
+
+// resolveSigningCA determines which CA secret (current or old) is used to sign a certificate requested via
+// SignedByCA, and sets it on the given certificateConfig.
+//
+// Client certificates are always renewed immediately (hence, signed with the current CA), while server certificates
+// are signed with the old CA until it doesn't exist anymore in the internal store. However, if the old CA's
+// remaining validity is shorter than the requested certificate's validity, the current CA is used instead so that
+// the issued leaf certificate does not outlive its signer.
+func (m *manager) resolveSigningCA(namespace, name string, certificateConfig *secretutils.CertificateSecretConfig, signedByCAOptions *SignedByCAOptions, options *GenerateOptions) error {
+	secrets, found := m.getFromStore(namespace, name)
+	if !found {
+		return fmt.Errorf("secrets for name %q not found in internal store", name)
+	}
+
+	secret := secrets.current
+	usingOldCA := certificateConfig.CertType == secretutils.ServerCert && !signedByCAOptions.UseCurrentCA && secrets.old != nil
+	if usingOldCA {
+		secret = *secrets.old
+	}
+
+	ca, err := secretutils.LoadCertificate(name, secret.obj.Data[secretutils.DataKeyPrivateKeyCA], secret.obj.Data[secretutils.DataKeyCertificateCA])
+	if err != nil {
+		return err
+	}
+
+	if usingOldCA && options.Validity > 0 && ca.Certificate.NotAfter.Before(m.clock.Now().Add(options.Validity)) {
+		m.logger.Info("Old CA's remaining validity is shorter than the requested certificate validity, signing with the current CA instead", "name", name, "oldCANotAfter", ca.Certificate.NotAfter, "requestedValidity", options.Validity)
 
-		ca, err := secretutils.LoadCertificate(name, secret.obj.Data[secretutils.DataKeyPrivateKeyCA], secret.obj.Data[secretutils.DataKeyCertificateCA])
+		secret = secrets.current
+		ca, err = secretutils.LoadCertificate(name, secret.obj.Data[secretutils.DataKeyPrivateKeyCA], secret.obj.Data[secretutils.DataKeyCertificateCA])
 		if err != nil {
 			return err
 		}
+	}
+
+	if err := validateIsCACertificate(ca); err != nil {
+		return err
+	}
+
+	certificateConfig.SigningCA = ca
+	options.signingCAName = pointer.String(name)
+	options.signingCAChecksum = pointer.String(kutil.TruncateLabelValue(secret.dataChecksum))
+	return nil
+}
 
-		certificateConfig.SigningCA = ca
-		options.signingCAChecksum = pointer.String(kutil.TruncateLabelValue(secret.dataChecksum))
+// InNamespace returns a function which overrides the namespace the secret is generated in, and under which it is
+// tracked in the internal store, for this Generate call only. If not set, the manager's default namespace (as passed
+// to New) is used. This allows a single manager instance to manage secrets with the same name across multiple
+// namespaces, e.g. for garden-runtime components.
+func InNamespace(namespace string) GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.namespace = namespace
 		return nil
 	}
 }
@@ -606,9 +1534,333 @@ func IgnoreConfigChecksumForCASecretName() GenerateOption {
 	}
 }
 
+// WithName returns a function which overrides the computed secret name with the given fixed name, e.g. so that
+// consumers can mount the secret at a well-known, predictable path. name must be a valid DNS-1123 subdomain.
+// Checksum-based regeneration still applies internally: since the name no longer changes to reflect a config or
+// signing CA change, Generate instead detects such a change by comparing the existing secret's checksum labels
+// against the desired ones, and regenerates in place (as if ForceRegenerate had been set) whenever they differ.
+func WithName(name string) GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		if errs := apivalidation.NameIsDNSSubdomain(name, false); len(errs) > 0 {
+			return fmt.Errorf("invalid name %q for WithName option: %s", name, strings.Join(errs, ", "))
+		}
+		options.name = name
+		return nil
+	}
+}
+
+// OnRotation returns a function which sets the callback that is invoked with the reason whenever Generate creates a
+// new secret for the given config instead of reusing an existing one.
+func OnRotation(fn func(RotationReason)) GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.onRotation = fn
+		return nil
+	}
+}
+
+// TLSSecretType returns a function which makes Generate additionally write the certificate and private key under the
+// standard 'tls.crt'/'tls.key' keys (alongside any existing keys) and results in the secret's type being set to
+// 'kubernetes.io/tls', so that consumers expecting the standard Kubernetes TLS secret shape (e.g. cert-manager,
+// ingress controllers) can use it directly. It only takes effect when Generate generates fresh secret material, i.e.
+// creates a brand-new secret or regenerates an existing one in place (ForceRegenerate, or a changed WithName secret).
+func TLSSecretType() GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.tlsSecretType = true
+		return nil
+	}
+}
+
+// SecretType returns a function which overrides the auto-detected type of the resulting secret with the given type,
+// e.g. 'kubernetes.io/dockerconfigjson' for a secret generated from a DockerConfigJSONSecretConfig. Generate returns
+// an error if the generated data does not carry the data key(s) required for the given type.
+func SecretType(secretType corev1.SecretType) GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.secretType = &secretType
+		return nil
+	}
+}
+
+// validateNoConflictingDataKeyOptions returns an error if the given options combine in a way that would make Generate
+// clobber a data key written by one option with another, producing a corrupt secret instead of failing loudly. For
+// example, TLSSecretType requires a non-empty 'tls.key'/'ca.key' data key, but PublicOnly strips exactly those keys
+// from the generated data.
+func validateNoConflictingDataKeyOptions(options *GenerateOptions) error {
+	if options.tlsSecretType && options.publicOnly {
+		return fmt.Errorf("TLSSecretType and PublicOnly conflict: TLSSecretType requires a private key under the 'tls.key'/'ca.key' data key, but PublicOnly removes it")
+	}
+
+	return nil
+}
+
+// validateSecretType returns an error if the given data does not carry the data key(s) required for the given
+// well-known Kubernetes secret type.
+func validateSecretType(secretType corev1.SecretType, data map[string][]byte) error {
+	requiredDataKeys := map[corev1.SecretType][]string{
+		corev1.SecretTypeDockerConfigJson: {corev1.DockerConfigJsonKey},
+		corev1.SecretTypeBasicAuth:        {corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey},
+		corev1.SecretTypeSSHAuth:          {corev1.SSHAuthPrivateKey},
+		corev1.SecretTypeTLS:              {corev1.TLSCertKey, corev1.TLSPrivateKeyKey},
+	}[secretType]
+
+	for _, key := range requiredDataKeys {
+		if len(data[key]) == 0 {
+			return fmt.Errorf("secret type %q requires a non-empty %q data key, but it is missing", secretType, key)
+		}
+	}
+
+	return nil
+}
+
+// PublicOnly returns a function which makes Generate omit the private key data keys ('tls.key'/'ca.key') from the
+// generated secret, leaving only the certificate/CA PEM data. This is useful for secrets which are meant to be
+// distributed as public trust material only. It only takes effect when Generate generates fresh secret material, i.e.
+// creates a brand-new secret or regenerates an existing one in place (ForceRegenerate, or a changed WithName secret).
+func PublicOnly() GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.publicOnly = true
+		return nil
+	}
+}
+
+// IncludeCABundle returns a function which makes Generate embed the current certificate bundle (as maintained for the
+// signing CA, i.e. the current and, if present, old CA certificate) of the CA configured via the SignedByCA option
+// under the 'ca.crt' data key of the generated leaf secret, instead of only the single signer certificate that is
+// embedded there by default. This allows consumers to validate the leaf certificate against a CA bundle which
+// already contains the new CA certificate during a CA rotation, without having to read a separate bundle secret.
+// For a ControlPlaneSecretConfig, the bundle is additionally embedded as the certificate-authority-data of any
+// generated kubeconfig (see ControlPlaneSecretConfig.CABundlePEM), so that the kubeconfig keeps working against an
+// API server presenting a certificate from either CA.
+// Since it is only embedded when Generate generates fresh secret material (a brand-new secret, or one regenerated in
+// place), the embedded bundle is kept current as the CA
+// rotates: whenever the signing CA's checksum changes, Generate computes a new secret name for the leaf certificate,
+// triggering a fresh embed of the then-current CA bundle. It only takes effect for CertificateSecretConfig and
+// ControlPlaneSecretConfig, and requires the SignedByCA option to also be set.
+func IncludeCABundle() GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.includeCABundle = true
+		return nil
+	}
+}
+
+// FailOnExisting returns a function which makes Generate return an error instead of silently adopting a pre-existing
+// secret found at the computed target name that isn't labeled with this manager's identity. Without this option, such
+// a secret is reused as-is (e.g. relied upon by the backwards-compatibility adoption logic in
+// keepExistingSecretsIfNeeded), which risks silently colliding with an unrelated secret if the computed name happens
+// to coincide with one. This option is evaluated for every kind of secret, including bundle secrets, but does not
+// affect the explicit AdoptBundleFromOtherIdentity mechanism.
+func FailOnExisting() GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.failOnExisting = true
+		return nil
+	}
+}
+
+// AdoptExisting restricts the backwards-compatibility adoption performed by keepExistingSecretsIfNeeded (e.g. for the
+// legacy 'ssh-keypair', 'ca-client', or 'kube-apiserver-etcd-encryption-key' secrets) to the config names listed in
+// legacyNames: a legacy secret is only adopted if the config being generated has one of these names, and a fresh
+// secret is generated otherwise, ignoring the legacy one entirely.
+//
+// Passing this option at all opts into the new, explicit behavior, even with zero legacyNames (which disables
+// adoption altogether for the call). Without it, Generate falls back to today's implicit behavior, which adopts any
+// matching legacy secret regardless of the config's name. This is a deprecation period aid; new callers should always
+// pass AdoptExisting explicitly (with the legacy names they actually still need to migrate) instead of relying on the
+// implicit fallback, which will eventually be removed.
+func AdoptExisting(legacyNames ...string) GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.adoptExisting = &legacyNames
+		return nil
+	}
+}
+
+// ForceRegenerate returns a function which makes Generate unconditionally mint fresh secret material for an already
+// existing secret, bypassing every stability heuristic (config checksum, signing CA checksum, rotation time). This is
+// intended for incident response, e.g. when a secret's material is suspected to be compromised and must be replaced
+// regardless of whether its configuration changed. Since none of the checksums change, the secret keeps its existing
+// name and is updated in place rather than replaced by a newly named one. If RotationStrategy is also set to
+// KeepOld, the overwritten material is kept available as the old secret in the internal store (but, unlike a regular
+// KeepOld rotation, not as a separately named secret in the cluster, since the name does not change). It has no
+// effect when Generate is about to create a secret for the first time.
+func ForceRegenerate() GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.forceRegenerate = true
+		return nil
+	}
+}
+
+// WithClock returns a function which overrides the clock used for this call's lifetime labels (issued-at-time and,
+// if Validity is also given, valid-until-time) and, for CertificateSecretConfig and ControlPlaneSecretConfig, the
+// certificate's NotBefore/NotAfter, instead of the manager's own clock and whatever the config's own Clock field is
+// set to. This gives every config type a single, uniform way to be generated as if at a specific reference time,
+// e.g. for reproducible builds or tests, without having to additionally set the config's Clock field by hand.
+func WithClock(c clock.Clock) GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.clock = c
+		return nil
+	}
+}
+
+// WithOwnerReference returns a function which sets the given owner reference on the generated secret, enabling
+// Kubernetes garbage collection to clean it up once the owner is deleted. The reference is also propagated to the
+// secret's bundle and old secrets (if any), and merging is idempotent, i.e. re-generating with the same owner
+// reference does not create duplicate entries.
+func WithOwnerReference(ownerRef metav1.OwnerReference) GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.ownerReference = &ownerRef
+		return nil
+	}
+}
+
+// WithAnnotations returns a function which merges the given annotations into the generated secret, e.g. to attach
+// `reloader.stakater.com/match`-style annotations which trigger a workload restart on rotation. Manager-owned
+// annotations (e.g. AnnotationKeySignedByCA or AnnotationKeyCertificateFingerprintSHA256) always take precedence over
+// a colliding key. Calling it multiple times merges the given maps, with later calls overriding earlier ones on key
+// collisions.
+func WithAnnotations(annotations map[string]string) GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.annotations = utils.MergeStringMaps(options.annotations, annotations)
+		return nil
+	}
+}
+
+// WithRenewalWindow returns a function which sets the AnnotationKeyNextRenewalTime annotation on the generated
+// certificate secret to its 'valid-until-time' minus window, recomputed on every Generate call from the certificate's
+// actual 'NotAfter'. This gives an external controller or reloader watching the
+// secret a reliable, precomputed point in time at which to expect it to change, e.g. for short-lived certificates
+// that are rotated well before an external process would otherwise notice their expiry. It is only considered for
+// certificate secrets; it is a no-op for other secret types.
+func WithRenewalWindow(window time.Duration) GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.renewalWindow = &window
+		return nil
+	}
+}
+
+// Mutable returns a function which creates/patches the secret with 'Immutable: nil', explicitly opting out of the
+// immutability that Generate enforces by default. This is only meant to be used for debugging purposes, e.g. to
+// manually edit a secret's contents while developing locally, and must NOT be used in production.
+func Mutable() GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.mutable = true
+		return nil
+	}
+}
+
 func isBundleSecret() GenerateOption {
 	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
 		options.isBundleSecret = true
 		return nil
 	}
 }
+
+// AdoptBundleFromOtherIdentity returns a function which sets the option to adopt an existing bundle secret matching
+// the 'bundle-for' label regardless of which manager identity created it, relabeling it to the current identity
+// instead of generating a brand-new bundle. This is useful when migrating between manager identities so that
+// consumers of the bundle secret don't observe it disappearing and reappearing under a new name.
+func AdoptBundleFromOtherIdentity() GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.adoptBundleFromOtherIdentity = true
+		return nil
+	}
+}
+
+// WithTrustBundleConfigMap returns a function which sets the option to additionally maintain a ConfigMap mirroring
+// the CA bundle secret's PEM content under the 'ca.crt' data key, labelled with 'trust-bundle-for'. It is created
+// and updated alongside the bundle secret, so that in-cluster clients which expect trust material as a ConfigMap
+// don't need to read the bundle secret directly. This is only evaluated for CA certificate configurations.
+func WithTrustBundleConfigMap() GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.maintainTrustBundleConfigMap = true
+		return nil
+	}
+}
+
+// WithSystemTrustStoreKey returns a function which sets the option to additionally populate the CA bundle secret's
+// DataKeySystemTrustStore ('ca-bundle.crt') data key with the same content as its DataKeyCertificateBundle
+// ('bundle.crt') key, so that it can be mounted directly into `/etc/ssl/certs`-style paths expected by components
+// that mount their OS trust store. This is only evaluated for CA certificate configurations.
+func WithSystemTrustStoreKey() GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.includeSystemTrustStoreKey = true
+		return nil
+	}
+}
+
+// WithStableNameAlias returns a function which sets the option to additionally maintain a mutable Secret named
+// exactly like the config (i.e. without the checksum/rotation suffix Generate appends to the immutable secret it
+// actually tracks), mirroring that secret's type and data. It is created and updated alongside the checksum-named
+// secret, so that consumers which need a stable name to mount (since the checksum-named one changes on every
+// regeneration) don't have to track the current checksum-named secret themselves. Since the alias is intentionally
+// not labelled with this manager's identity, Cleanup never considers or deletes it as a stale secret of its own;
+// callers that no longer need the alias are responsible for deleting it themselves (e.g. via an owner reference).
+func WithStableNameAlias() GenerateOption {
+	return func(_ Interface, _ secretutils.ConfigInterface, options *GenerateOptions) error {
+		options.maintainStableNameAlias = true
+		return nil
+	}
+}
+
+// WithServiceClusterIP returns a function which appends the given IP to the 'IPAddresses' SAN of a
+// CertificateSecretConfig, e.g. to include an API server's cluster service IP (such as '10.0.0.1') into its server
+// certificate. Since it mutates the config before Generate computes the config checksum, the IP is automatically
+// taken into account when deciding whether the certificate needs to be regenerated.
+func WithServiceClusterIP(ip net.IP) GenerateOption {
+	return func(_ Interface, config secretutils.ConfigInterface, _ *GenerateOptions) error {
+		if ip == nil {
+			return fmt.Errorf("service cluster IP must not be empty")
+		}
+
+		var certificateConfig *secretutils.CertificateSecretConfig
+		switch cfg := config.(type) {
+		case *secretutils.CertificateSecretConfig:
+			certificateConfig = cfg
+		case *secretutils.ControlPlaneSecretConfig:
+			certificateConfig = cfg.CertificateSecretConfig
+		default:
+			return fmt.Errorf("could not apply option to %T, expected *secrets.CertificateSecretConfig", config)
+		}
+
+		certificateConfig.IPAddresses = append(certificateConfig.IPAddresses, ip)
+		return nil
+	}
+}
+
+// WithLoopbackSANs returns a function which appends the loopback SANs ('127.0.0.1', '::1', and 'localhost') to a
+// CertificateSecretConfig's IPAddresses and DNSNames, e.g. for server certificates of control-plane components that
+// also talk to themselves over loopback. SANs already present, whether explicitly configured or added by a prior
+// call, are not duplicated. Since it mutates the config before Generate computes the config checksum, the loopback
+// SANs are automatically taken into account when deciding whether the certificate needs to be regenerated.
+func WithLoopbackSANs() GenerateOption {
+	return func(_ Interface, config secretutils.ConfigInterface, _ *GenerateOptions) error {
+		var certificateConfig *secretutils.CertificateSecretConfig
+		switch cfg := config.(type) {
+		case *secretutils.CertificateSecretConfig:
+			certificateConfig = cfg
+		case *secretutils.ControlPlaneSecretConfig:
+			certificateConfig = cfg.CertificateSecretConfig
+		default:
+			return fmt.Errorf("could not apply option to %T, expected *secrets.CertificateSecretConfig", config)
+		}
+
+		if !utils.ValueExists("localhost", certificateConfig.DNSNames) {
+			certificateConfig.DNSNames = append(certificateConfig.DNSNames, "localhost")
+		}
+
+		for _, ip := range []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback} {
+			if !containsIP(certificateConfig.IPAddresses, ip) {
+				certificateConfig.IPAddresses = append(certificateConfig.IPAddresses, ip)
+			}
+		}
+
+		return nil
+	}
+}
+
+// containsIP returns whether ip is contained in ips.
+func containsIP(ips []net.IP, ip net.IP) bool {
+	for _, existing := range ips {
+		if existing.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -16,10 +16,18 @@ package manager
 
 import (
 	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
 	"strconv"
 	"time"
 
 	"github.com/gardener/gardener/pkg/utils"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
 	"github.com/gardener/gardener/pkg/utils/test"
 
@@ -28,11 +36,15 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/clock"
 	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
 )
 
 var _ = BeforeSuite(func() {
@@ -81,13 +93,42 @@ var _ = Describe("Generate", func() {
 				expectSecretWasCreated(ctx, fakeClient, secret)
 
 				By("verifying internal store reflects changes")
-				secretInfos, found := m.getFromStore(name)
+				secretInfos, found := m.getFromStore(namespace, name)
 				Expect(found).To(BeTrue())
 				Expect(secretInfos.current.obj).To(Equal(secret))
 				Expect(secretInfos.old).To(BeNil())
 				Expect(secretInfos.bundle).To(BeNil())
 			})
 
+			It("should generate independent secrets for the same name in different namespaces when InNamespace is used", func() {
+				otherNamespace := "shoot--foo--other"
+
+				By("generating the secret in the manager's default namespace")
+				defaultSecret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, defaultSecret)
+				Expect(defaultSecret.Namespace).To(Equal(namespace))
+
+				By("generating the secret in another namespace")
+				otherSecret, err := m.Generate(ctx, config, InNamespace(otherNamespace))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, otherSecret)
+				Expect(otherSecret.Namespace).To(Equal(otherNamespace))
+
+				By("verifying the internal store tracks both entries independently")
+				defaultSecretInfos, found := m.getFromStore(namespace, name)
+				Expect(found).To(BeTrue())
+				Expect(defaultSecretInfos.current.obj).To(Equal(defaultSecret))
+
+				otherSecretInfos, found := m.getFromStore(otherNamespace, name)
+				Expect(found).To(BeTrue())
+				Expect(otherSecretInfos.current.obj).To(Equal(otherSecret))
+
+				By("verifying both secrets carry the manager's identity label")
+				Expect(defaultSecret.Labels[LabelKeyManagerIdentity]).To(Equal(identity))
+				Expect(otherSecret.Labels[LabelKeyManagerIdentity]).To(Equal(identity))
+			})
+
 			It("should maintain the lifetime labels (w/o validity)", func() {
 				By("generating new secret")
 				secret, err := m.Generate(ctx, config)
@@ -133,7 +174,7 @@ var _ = Describe("Generate", func() {
 				expectSecretWasCreated(ctx, fakeClient, newSecret)
 
 				By("verifying internal store reflects changes")
-				secretInfos, found := m.getFromStore(name)
+				secretInfos, found := m.getFromStore(namespace, name)
 				Expect(found).To(BeTrue())
 				Expect(secretInfos.current.obj).To(Equal(newSecret))
 				Expect(secretInfos.old).To(BeNil())
@@ -156,13 +197,51 @@ var _ = Describe("Generate", func() {
 				expectSecretWasCreated(ctx, fakeClient, newSecret)
 
 				By("verifying internal store reflects changes")
-				secretInfos, found := m.getFromStore(name)
+				secretInfos, found := m.getFromStore(namespace, name)
 				Expect(found).To(BeTrue())
 				Expect(secretInfos.current.obj).To(Equal(newSecret))
 				Expect(secretInfos.old).To(BeNil())
 				Expect(secretInfos.bundle).To(BeNil())
 			})
 
+			It("should invoke the OnRotation callback with the correct reason for first generation, config change, and rotation", func() {
+				var reasons []RotationReason
+				onRotation := OnRotation(func(reason RotationReason) { reasons = append(reasons, reason) })
+
+				By("generating new secret")
+				_, err := m.Generate(ctx, config, onRotation)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reasons).To(ConsistOf(RotationReasonFirstGeneration))
+
+				By("changing secret config and generate again")
+				config.PasswordLength = 4
+				_, err = m.Generate(ctx, config, onRotation)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reasons).To(ConsistOf(RotationReasonFirstGeneration, RotationReasonConfigChange))
+
+				By("changing last rotation initiation time and generate again")
+				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{name: time.Now()})
+				Expect(err).NotTo(HaveOccurred())
+				m = mgr.(*manager)
+
+				_, err = m.Generate(ctx, config, onRotation)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reasons).To(ConsistOf(RotationReasonFirstGeneration, RotationReasonConfigChange, RotationReasonRotationTriggered))
+			})
+
+			It("should not invoke the OnRotation callback when the secret is unchanged", func() {
+				called := false
+				onRotation := OnRotation(func(RotationReason) { called = true })
+
+				_, err := m.Generate(ctx, config, onRotation)
+				Expect(err).NotTo(HaveOccurred())
+				called = false
+
+				_, err = m.Generate(ctx, config, onRotation)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(called).To(BeFalse())
+			})
+
 			It("should store the old secret if rotation strategy is KeepOld", func() {
 				By("generating new secret")
 				secret, err := m.Generate(ctx, config)
@@ -176,7 +255,7 @@ var _ = Describe("Generate", func() {
 				expectSecretWasCreated(ctx, fakeClient, newSecret)
 
 				By("verifying internal store reflects changes")
-				secretInfos, found := m.getFromStore(name)
+				secretInfos, found := m.getFromStore(namespace, name)
 				Expect(found).To(BeTrue())
 				Expect(secretInfos.current.obj).To(Equal(newSecret))
 				Expect(secretInfos.old.obj).To(Equal(withoutTypeMeta(secret)))
@@ -196,13 +275,142 @@ var _ = Describe("Generate", func() {
 				expectSecretWasCreated(ctx, fakeClient, newSecret)
 
 				By("verifying internal store reflects changes")
-				secretInfos, found := m.getFromStore(name)
+				secretInfos, found := m.getFromStore(namespace, name)
 				Expect(found).To(BeTrue())
 				Expect(secretInfos.current.obj).To(Equal(newSecret))
 				Expect(secretInfos.old).To(BeNil())
 				Expect(secretInfos.bundle).To(BeNil())
 			})
 
+			It("should retain the n most recent old secrets when rotation strategy is KeepLastN", func() {
+				By("generating new secret")
+				first, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, first)
+
+				By("rotating a first time with KeepLastN(2)")
+				fakeClock.Step(time.Minute)
+				config.PasswordLength = 4
+				second, err := m.Generate(ctx, config, Rotate(KeepLastN(2)))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, second)
+
+				By("rotating a second time with KeepLastN(2)")
+				fakeClock.Step(time.Minute)
+				config.PasswordLength = 5
+				third, err := m.Generate(ctx, config, Rotate(KeepLastN(2)))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, third)
+
+				By("rotating a third time with KeepLastN(2)")
+				fakeClock.Step(time.Minute)
+				config.PasswordLength = 6
+				fourth, err := m.Generate(ctx, config, Rotate(KeepLastN(2)))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, fourth)
+
+				By("verifying only the two most recent old secrets are retained")
+				secretInfos, found := m.getFromStore(namespace, name)
+				Expect(found).To(BeTrue())
+				Expect(secretInfos.current.obj).To(Equal(fourth))
+				Expect(secretInfos.old.obj).To(Equal(withoutTypeMeta(third)))
+				Expect(m.GetRetained(name)).To(Equal([]*corev1.Secret{withoutTypeMeta(third), withoutTypeMeta(second)}))
+
+				By("verifying the oldest secret beyond the limit was deleted from the cluster")
+				secretList := &corev1.SecretList{}
+				Expect(fakeClient.List(ctx, secretList, client.InNamespace(namespace))).To(Succeed())
+				Expect(secretList.Items).To(HaveLen(3))
+			})
+
+			It("should reject a name which is not a valid DNS-1123 subdomain", func() {
+				_, err := m.Generate(ctx, config, WithName("Not_Valid"))
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("should pin the secret to the given name and keep regenerating it in place on config change", func() {
+				By("generating with a pinned name")
+				secret, err := m.Generate(ctx, config, WithName("pinned-name"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret.Name).To(Equal("pinned-name"))
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				By("changing secret config and generating again with the same pinned name")
+				config.PasswordLength = 4
+				newSecret, err := m.Generate(ctx, config, WithName("pinned-name"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newSecret.Name).To(Equal("pinned-name"))
+				Expect(newSecret.Data).NotTo(Equal(secret.Data))
+
+				By("verifying the secret was updated in place rather than replaced by a new one")
+				secretList := &corev1.SecretList{}
+				Expect(fakeClient.List(ctx, secretList, client.InNamespace(namespace))).To(Succeed())
+				Expect(secretList.Items).To(HaveLen(1))
+			})
+
+			It("should keep the previous password valid alongside the new one when rotating with KeepOld", func() {
+				By("generating new secret")
+				secret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+				oldPassword := string(secret.Data[secretutils.DataKeyPassword])
+
+				By("changing secret config and generate again with KeepOld strategy")
+				config.PasswordLength = 4
+				newSecret, err := m.Generate(ctx, config, Rotate(KeepOld))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newSecret)
+				newPassword := string(newSecret.Data[secretutils.DataKeyPassword])
+
+				Expect(newPassword).NotTo(Equal(oldPassword))
+				Expect(string(newSecret.Data[secretutils.DataKeySHA1Auth])).To(Equal(
+					string(utils.CreateSHA1Secret([]byte(config.Username), []byte(newPassword))) + "\n" +
+						string(utils.CreateSHA1Secret([]byte(config.Username), []byte(oldPassword))),
+				))
+			})
+
+			It("should log structured decisions for a create-then-rotate sequence", func() {
+				sink := &recordingLogSink{}
+
+				By("generating new secret")
+				mgr, err := New(ctx, logr.New(sink), fakeClock, fakeClient, namespace, identity, nil)
+				Expect(err).NotTo(HaveOccurred())
+				m = mgr.(*manager)
+
+				secret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				entry, found := sink.find("created")
+				Expect(found).To(BeTrue())
+				Expect(entry.values).To(And(
+					HaveKeyWithValue("name", name),
+					HaveKeyWithValue("reason", string(RotationReasonFirstGeneration)),
+				))
+
+				By("changing last rotation initiation time and generating again with KeepOld strategy")
+				mgr, err = New(ctx, logr.New(sink), fakeClock, fakeClient, namespace, identity, map[string]time.Time{name: time.Now()})
+				Expect(err).NotTo(HaveOccurred())
+				m = mgr.(*manager)
+
+				newSecret, err := m.Generate(ctx, config, Rotate(KeepOld))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newSecret)
+
+				entries := sink.findAll("created")
+				Expect(entries).To(HaveLen(2))
+				Expect(entries[1].values).To(And(
+					HaveKeyWithValue("name", name),
+					HaveKeyWithValue("reason", string(RotationReasonRotationTriggered)),
+				))
+
+				entry, found = sink.find("rotated-keep-old")
+				Expect(found).To(BeTrue())
+				Expect(entry.values).To(And(
+					HaveKeyWithValue("name", name),
+					HaveKeyWithValue("rotationStrategy", string(KeepOld)),
+				))
+			})
+
 			It("should reconcile the secret", func() {
 				By("generating new secret")
 				secret, err := m.Generate(ctx, config)
@@ -230,6 +438,241 @@ var _ = Describe("Generate", func() {
 				))
 				Expect(foundSecret.Immutable).To(PointTo(BeTrue()))
 			})
+
+			It("should create and reconcile the secret as mutable when Mutable is used", func() {
+				By("generating new secret")
+				secret, err := m.Generate(ctx, config, Mutable())
+				Expect(err).NotTo(HaveOccurred())
+
+				foundSecret := &corev1.Secret{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), foundSecret)).To(Succeed())
+				Expect(foundSecret.Immutable).To(BeNil())
+
+				By("changing options and generate again")
+				secret, err = m.Generate(ctx, config, Mutable(), Persist())
+				Expect(err).NotTo(HaveOccurred())
+
+				By("verifying labels got reconciled and secret stayed mutable")
+				foundSecret = &corev1.Secret{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), foundSecret)).To(Succeed())
+				Expect(foundSecret.Labels).To(HaveKeyWithValue("persist", "true"))
+				Expect(foundSecret.Immutable).To(BeNil())
+			})
+
+			It("should set the owner reference on current and old secrets when WithOwnerReference is used", func() {
+				ownerRef := metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "parent", UID: "parent-uid"}
+
+				By("generating new secret")
+				secret, err := m.Generate(ctx, config, WithOwnerReference(ownerRef))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret.OwnerReferences).To(ConsistOf(ownerRef))
+
+				By("changing secret config and generate again with KeepOld strategy")
+				config.PasswordLength = 4
+				newSecret, err := m.Generate(ctx, config, Rotate(KeepOld), WithOwnerReference(ownerRef))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newSecret.OwnerReferences).To(ConsistOf(ownerRef))
+
+				By("verifying the old secret also received the owner reference")
+				secretInfos, found := m.getFromStore(namespace, name)
+				Expect(found).To(BeTrue())
+				Expect(secretInfos.old.obj.OwnerReferences).To(ConsistOf(ownerRef))
+
+				By("generating again and verifying the owner reference is not duplicated")
+				newSecret, err = m.Generate(ctx, config, Rotate(KeepOld), WithOwnerReference(ownerRef))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newSecret.OwnerReferences).To(ConsistOf(ownerRef))
+			})
+
+			It("should merge the given annotations into the secret and keep them across a reconcile-only Generate call", func() {
+				By("generating new secret with custom annotations")
+				secret, err := m.Generate(ctx, config, WithAnnotations(map[string]string{"reloader.stakater.com/match": "true"}))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret.Annotations).To(HaveKeyWithValue("reloader.stakater.com/match", "true"))
+
+				By("generating again without config changes")
+				newSecret, err := m.Generate(ctx, config, WithAnnotations(map[string]string{"reloader.stakater.com/match": "true"}))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newSecret.Annotations).To(HaveKeyWithValue("reloader.stakater.com/match", "true"))
+			})
+
+			It("should retry and succeed when the patch to reconcile the secret conflicts transiently", func() {
+				conflictingClient := &conflictOncePatchClient{Client: fakeClient}
+
+				mgr, err := New(ctx, logr.Discard(), fakeClock, conflictingClient, namespace, identity, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				secret, err := mgr.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+				Expect(conflictingClient.patchCalls).To(BeNumerically(">=", 2))
+			})
+
+			It("should fail when FailOnExisting is used and a foreign secret already exists at the target name", func() {
+				secretName, err := m.ComputeSecretName(config)
+				Expect(err).NotTo(HaveOccurred())
+
+				foreignSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      secretName,
+						Namespace: namespace,
+					},
+					Data: map[string][]byte{"foo": []byte("bar")},
+				}
+				Expect(fakeClient.Create(ctx, foreignSecret)).To(Succeed())
+
+				_, err = m.Generate(ctx, config, FailOnExisting())
+				Expect(err).To(MatchError(ContainSubstring("refusing to adopt existing secret")))
+			})
+
+			It("should succeed when FailOnExisting is used and the existing secret is already managed by this identity", func() {
+				secret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				sameSecret, err := m.Generate(ctx, config, FailOnExisting())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sameSecret).To(Equal(secret))
+			})
+		})
+
+		Context("for symmetric key secrets", func() {
+			var config *secretutils.SymmetricKeySecretConfig
+
+			BeforeEach(func() {
+				config = &secretutils.SymmetricKeySecretConfig{
+					Name: name,
+					Size: 32,
+				}
+			})
+
+			It("should generate a new secret with a key of the configured length", func() {
+				secret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+				Expect(secret.Data[secretutils.DataKeyPreSharedKey]).To(HaveLen(32))
+			})
+
+			It("should generate the same key again for an unchanged config", func() {
+				secret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				sameSecret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sameSecret).To(Equal(secret))
+			})
+
+			It("should keep the previous key available as the old secret if rotation strategy is KeepOld", func() {
+				By("generating new secret")
+				secret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				By("changing secret config and generate again with KeepOld strategy")
+				config.Size = 16
+				newSecret, err := m.Generate(ctx, config, Rotate(KeepOld))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newSecret)
+				Expect(newSecret.Data[secretutils.DataKeyPreSharedKey]).NotTo(Equal(secret.Data[secretutils.DataKeyPreSharedKey]))
+
+				By("verifying internal store reflects changes")
+				secretInfos, found := m.getFromStore(namespace, name)
+				Expect(found).To(BeTrue())
+				Expect(secretInfos.current.obj).To(Equal(newSecret))
+				Expect(secretInfos.old.obj).To(Equal(withoutTypeMeta(secret)))
+			})
+		})
+
+		Context("for HMAC key secrets", func() {
+			var config *secretutils.HMACKeySecretConfig
+
+			BeforeEach(func() {
+				config = &secretutils.HMACKeySecretConfig{
+					Name:      name,
+					KeyLength: 32,
+				}
+			})
+
+			It("should generate a new secret with a key of the configured length", func() {
+				secret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+				Expect(secret.Data[secretutils.DataKeyHMACSecretKey]).To(HaveLen(32))
+			})
+
+			It("should generate the same key again for an unchanged config", func() {
+				secret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				sameSecret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sameSecret).To(Equal(secret))
+			})
+
+			It("should keep the previous key available as the old secret if rotation strategy is KeepOld", func() {
+				By("generating new secret")
+				secret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				By("changing secret config and generate again with KeepOld strategy")
+				config.KeyLength = 48
+				newSecret, err := m.Generate(ctx, config, Rotate(KeepOld))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newSecret)
+				Expect(newSecret.Data[secretutils.DataKeyHMACSecretKey]).NotTo(Equal(secret.Data[secretutils.DataKeyHMACSecretKey]))
+
+				By("verifying internal store reflects changes")
+				secretInfos, found := m.getFromStore(namespace, name)
+				Expect(found).To(BeTrue())
+				Expect(secretInfos.current.obj).To(Equal(newSecret))
+				Expect(secretInfos.old.obj).To(Equal(withoutTypeMeta(secret)))
+			})
+		})
+
+		Context("for docker config json secrets", func() {
+			var config *secretutils.DockerConfigJSONSecretConfig
+
+			BeforeEach(func() {
+				config = &secretutils.DockerConfigJSONSecretConfig{
+					Name:     name,
+					Server:   "registry.example.com",
+					Username: "user",
+					Password: "pass",
+				}
+			})
+
+			It("should generate a secret of type 'kubernetes.io/dockerconfigjson' with the expected payload when SecretType is used", func() {
+				secret, err := m.Generate(ctx, config, SecretType(corev1.SecretTypeDockerConfigJson))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				Expect(secret.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+				Expect(secret.Data).To(HaveKey(corev1.DockerConfigJsonKey))
+				Expect(secret.Data[corev1.DockerConfigJsonKey]).To(ContainSubstring("registry.example.com"))
+			})
+
+			It("should default to an Opaque secret when SecretType is not used", func() {
+				secret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				Expect(secret.Type).To(Equal(corev1.SecretTypeOpaque))
+			})
+
+			It("should return an error when the requested type does not match the generated data", func() {
+				config = &secretutils.DockerConfigJSONSecretConfig{
+					Name:     name,
+					Server:   "registry.example.com",
+					Username: "user",
+					Password: "pass",
+				}
+
+				_, err := m.Generate(ctx, config, SecretType(corev1.SecretTypeBasicAuth))
+				Expect(err).To(MatchError(ContainSubstring("requires a non-empty")))
+			})
 		})
 
 		Context("for CA certificate secrets", func() {
@@ -260,13 +703,26 @@ var _ = Describe("Generate", func() {
 				Expect(secretList.Items).To(HaveLen(1))
 
 				By("verifying internal store reflects changes")
-				secretInfos, found := m.getFromStore(name)
+				secretInfos, found := m.getFromStore(namespace, name)
 				Expect(found).To(BeTrue())
 				Expect(secretInfos.current.obj).To(Equal(secret))
 				Expect(secretInfos.old).To(BeNil())
 				Expect(secretInfos.bundle.obj).To(Equal(withTypeMeta(&secretList.Items[0])))
 			})
 
+			It("should round-trip the generated CA certificate through LoadCertificateFromSecret", func() {
+				secret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+
+				certificate, err := secretutils.LoadCertificateFromSecret(name, secret.Data)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(certificate.CertificatePEM).To(Equal(secret.Data[secretutils.DataKeyCertificateCA]))
+				Expect(certificate.PrivateKeyPEM).To(Equal(secret.Data[secretutils.DataKeyPrivateKeyCA]))
+				Expect(certificate.Certificate).NotTo(BeNil())
+				Expect(certificate.PrivateKey).NotTo(BeNil())
+			})
+
 			It("should maintain the lifetime labels (w/o custom validity)", func() {
 				By("generating new secret")
 				config.Clock = fakeClock
@@ -284,21 +740,111 @@ var _ = Describe("Generate", func() {
 				))
 			})
 
-			It("should maintain the lifetime labels (w/ custom validity which is ignored for certificates)", func() {
+			It("should set and update the SHA-256 certificate fingerprint annotation", func() {
 				By("generating new secret")
-				config.Clock = fakeClock
-				secret, err := m.Generate(ctx, config, Validity(time.Hour))
+				secret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("computing the fingerprint independently")
+				certificate, err := secretutils.LoadCertificateFromSecret(name, secret.Data)
 				Expect(err).NotTo(HaveOccurred())
+				fingerprint := sha256.Sum256(certificate.Certificate.Raw)
 
 				By("reading created secret from system")
 				foundSecret := &corev1.Secret{}
 				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), foundSecret)).To(Succeed())
+				Expect(foundSecret.Annotations).To(HaveKeyWithValue("cert-fingerprint-sha256", hex.EncodeToString(fingerprint[:])))
 
-				By("verifying labels")
-				Expect(foundSecret.Labels).To(And(
-					HaveKeyWithValue("issued-at-time", strconv.FormatInt(fakeClock.Now().Unix(), 10)),
-					HaveKeyWithValue("valid-until-time", strconv.FormatInt(fakeClock.Now().AddDate(10, 0, 0).Unix(), 10)),
-				))
+				By("rotating the CA secret")
+				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{name: time.Now()})
+				Expect(err).NotTo(HaveOccurred())
+				m = mgr.(*manager)
+
+				newSecret, err := m.Generate(ctx, config, Rotate(KeepOld))
+				Expect(err).NotTo(HaveOccurred())
+
+				By("computing the new fingerprint independently")
+				newCertificate, err := secretutils.LoadCertificateFromSecret(name, newSecret.Data)
+				Expect(err).NotTo(HaveOccurred())
+				newFingerprint := sha256.Sum256(newCertificate.Certificate.Raw)
+				Expect(newFingerprint).NotTo(Equal(fingerprint))
+
+				By("verifying the annotation was updated on the rotated secret")
+				foundNewSecret := &corev1.Secret{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(newSecret), foundNewSecret)).To(Succeed())
+				Expect(foundNewSecret.Annotations).To(HaveKeyWithValue("cert-fingerprint-sha256", hex.EncodeToString(newFingerprint[:])))
+			})
+
+			It("should set the data-checksum annotation, keep it stable on a reconcile-only Generate, and change it on rotation", func() {
+				By("generating new secret")
+				secret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				dataChecksum := secret.Annotations[AnnotationKeyDataChecksum]
+				Expect(dataChecksum).To(Equal(utils.ComputeSecretChecksum(secret.Data)))
+
+				By("generating again without any change")
+				unchangedSecret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unchangedSecret.Annotations[AnnotationKeyDataChecksum]).To(Equal(dataChecksum))
+
+				By("rotating the CA secret")
+				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{name: time.Now()})
+				Expect(err).NotTo(HaveOccurred())
+				m = mgr.(*manager)
+
+				newSecret, err := m.Generate(ctx, config, Rotate(KeepOld))
+				Expect(err).NotTo(HaveOccurred())
+
+				By("verifying the annotation changed on the rotated secret")
+				Expect(newSecret.Annotations[AnnotationKeyDataChecksum]).NotTo(Equal(dataChecksum))
+				Expect(newSecret.Annotations[AnnotationKeyDataChecksum]).To(Equal(utils.ComputeSecretChecksum(newSecret.Data)))
+			})
+
+			It("should set the owner reference on the current secret and its bundle when WithOwnerReference is used", func() {
+				ownerRef := metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "parent", UID: "parent-uid"}
+
+				secret, err := m.Generate(ctx, config, WithOwnerReference(ownerRef))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret.OwnerReferences).To(ConsistOf(ownerRef))
+
+				secretInfos, found := m.getFromStore(namespace, name)
+				Expect(found).To(BeTrue())
+				Expect(secretInfos.bundle.obj.OwnerReferences).To(ConsistOf(ownerRef))
+			})
+
+			It("should maintain the lifetime labels (w/ custom validity which is ignored for certificates)", func() {
+				By("generating new secret")
+				config.Clock = fakeClock
+				secret, err := m.Generate(ctx, config, Validity(time.Hour))
+				Expect(err).NotTo(HaveOccurred())
+
+				By("reading created secret from system")
+				foundSecret := &corev1.Secret{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), foundSecret)).To(Succeed())
+
+				By("verifying labels")
+				Expect(foundSecret.Labels).To(And(
+					HaveKeyWithValue("issued-at-time", strconv.FormatInt(fakeClock.Now().Unix(), 10)),
+					HaveKeyWithValue("valid-until-time", strconv.FormatInt(fakeClock.Now().AddDate(10, 0, 0).Unix(), 10)),
+				))
+			})
+
+			It("should maintain the lifetime labels according to the config's own validity", func() {
+				By("generating new secret")
+				config.Clock = fakeClock
+				config.Validity = utils.DurationPtr(2 * 365 * 24 * time.Hour)
+				secret, err := m.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("reading created secret from system")
+				foundSecret := &corev1.Secret{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), foundSecret)).To(Succeed())
+
+				By("verifying labels")
+				Expect(foundSecret.Labels).To(And(
+					HaveKeyWithValue("issued-at-time", strconv.FormatInt(fakeClock.Now().Unix(), 10)),
+					HaveKeyWithValue("valid-until-time", strconv.FormatInt(fakeClock.Now().Add(*config.Validity).Unix(), 10)),
+				))
 			})
 
 			It("should generate a new CA secret and ignore the config checksum for its name", func() {
@@ -309,6 +855,37 @@ var _ = Describe("Generate", func() {
 				Expect(secret.Name).To(Equal(name))
 			})
 
+			It("should additionally write the standard tls.crt/tls.key keys and set the TLS secret type when TLSSecretType is used", func() {
+				By("generating new secret")
+				secret, err := m.Generate(ctx, config, TLSSecretType())
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				By("verifying secret type and data")
+				Expect(secret.Type).To(Equal(corev1.SecretTypeTLS))
+				Expect(secret.Data).To(HaveKeyWithValue(secretutils.DataKeyCertificate, secret.Data[secretutils.DataKeyCertificateCA]))
+				Expect(secret.Data).To(HaveKeyWithValue(secretutils.DataKeyPrivateKey, secret.Data[secretutils.DataKeyPrivateKeyCA]))
+				Expect(secret.Data).To(HaveKey(secretutils.DataKeyCertificateCA))
+				Expect(secret.Data).To(HaveKey(secretutils.DataKeyPrivateKeyCA))
+			})
+
+			It("should omit the private key data keys when PublicOnly is used", func() {
+				By("generating new secret")
+				secret, err := m.Generate(ctx, config, PublicOnly())
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				By("verifying secret data")
+				Expect(secret.Data).To(HaveKey(secretutils.DataKeyCertificateCA))
+				Expect(secret.Data).NotTo(HaveKey(secretutils.DataKeyPrivateKeyCA))
+				Expect(secret.Data).NotTo(HaveKey(secretutils.DataKeyPrivateKey))
+			})
+
+			It("should return an error instead of producing a corrupt secret when TLSSecretType and PublicOnly are combined", func() {
+				_, err := m.Generate(ctx, config, TLSSecretType(), PublicOnly())
+				Expect(err).To(MatchError(ContainSubstring("TLSSecretType and PublicOnly conflict")))
+			})
+
 			It("should rotate a CA secret and add old and new to the corresponding bundle", func() {
 				By("generating new secret")
 				secret, err := m.Generate(ctx, config)
@@ -316,7 +893,7 @@ var _ = Describe("Generate", func() {
 				expectSecretWasCreated(ctx, fakeClient, secret)
 
 				By("storing old bundle secret")
-				secretInfos, found := m.getFromStore(name)
+				secretInfos, found := m.getFromStore(namespace, name)
 				Expect(found).To(BeTrue())
 				oldBundleSecret := secretInfos.bundle.obj
 
@@ -339,12 +916,104 @@ var _ = Describe("Generate", func() {
 				Expect(secretList.Items).To(HaveLen(2))
 
 				By("verifying internal store reflects changes")
-				secretInfos, found = m.getFromStore(name)
+				secretInfos, found = m.getFromStore(namespace, name)
 				Expect(found).To(BeTrue())
 				Expect(secretInfos.current.obj).To(Equal(newSecret))
 				Expect(secretInfos.old.obj).To(Equal(withoutTypeMeta(secret)))
 				Expect(secretInfos.bundle.obj).NotTo(PointTo(Equal(oldBundleSecret)))
 			})
+
+			It("should maintain a trust bundle ConfigMap in sync with the bundle secret when WithTrustBundleConfigMap is used", func() {
+				By("generating new secret")
+				secret, err := m.Generate(ctx, config, WithTrustBundleConfigMap())
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				secretInfos, found := m.getFromStore(namespace, name)
+				Expect(found).To(BeTrue())
+				bundleSecret := secretInfos.bundle.obj
+
+				By("verifying the trust bundle ConfigMap was created alongside the bundle secret")
+				configMap := &corev1.ConfigMap{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(bundleSecret), configMap)).To(Succeed())
+				Expect(configMap.Labels).To(HaveKeyWithValue("trust-bundle-for", name))
+				Expect(configMap.Data).To(HaveKeyWithValue(secretutils.DataKeyCertificateCA, string(bundleSecret.Data[secretutils.DataKeyCertificateBundle])))
+
+				By("rotating the CA secret")
+				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{name: time.Now()})
+				Expect(err).NotTo(HaveOccurred())
+				m = mgr.(*manager)
+
+				_, err = m.Generate(ctx, config, Rotate(KeepOld), WithTrustBundleConfigMap())
+				Expect(err).NotTo(HaveOccurred())
+
+				secretInfos, found = m.getFromStore(namespace, name)
+				Expect(found).To(BeTrue())
+				rotatedBundleSecret := secretInfos.bundle.obj
+
+				By("verifying the trust bundle ConfigMap was updated to the rotated bundle secret's content")
+				configMap = &corev1.ConfigMap{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(rotatedBundleSecret), configMap)).To(Succeed())
+				Expect(configMap.Data).To(HaveKeyWithValue(secretutils.DataKeyCertificateCA, string(rotatedBundleSecret.Data[secretutils.DataKeyCertificateBundle])))
+			})
+
+			It("should additionally populate the 'ca-bundle.crt' data key with identical, valid PEM when WithSystemTrustStoreKey is used", func() {
+				_, err := m.Generate(ctx, config, WithSystemTrustStoreKey())
+				Expect(err).NotTo(HaveOccurred())
+
+				secretInfos, found := m.getFromStore(namespace, name)
+				Expect(found).To(BeTrue())
+				bundleSecret := secretInfos.bundle.obj
+
+				Expect(bundleSecret.Data).To(HaveKey(secretutils.DataKeySystemTrustStore))
+				Expect(bundleSecret.Data[secretutils.DataKeySystemTrustStore]).To(Equal(bundleSecret.Data[secretutils.DataKeyCertificateBundle]))
+
+				certificates, err := utils.DecodeCertificate(bundleSecret.Data[secretutils.DataKeySystemTrustStore])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(certificates).NotTo(BeNil())
+			})
+
+			It("should adopt an existing bundle secret from another manager identity instead of creating a new one", func() {
+				By("seeding a bundle secret created by a foreign manager identity")
+				foreignBundle := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "ca-bundle-foreign",
+						Namespace: namespace,
+						Labels: map[string]string{
+							"name":             name + "-bundle",
+							"managed-by":       "secrets-manager",
+							"manager-identity": "other-identity",
+							"bundle-for":       name,
+						},
+					},
+					Data: map[string][]byte{"bundle.crt": []byte("foreign-bundle-data")},
+				}
+				Expect(fakeClient.Create(ctx, foreignBundle)).To(Succeed())
+
+				By("generating the CA secret with bundle adoption enabled")
+				secret, err := m.Generate(ctx, config, AdoptBundleFromOtherIdentity())
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				By("verifying the foreign bundle secret was adopted and relabeled instead of replaced")
+				adoptedBundle := &corev1.Secret{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(foreignBundle), adoptedBundle)).To(Succeed())
+				Expect(adoptedBundle.Labels).To(HaveKeyWithValue("manager-identity", "test"))
+				Expect(adoptedBundle.Data).To(Equal(foreignBundle.Data))
+
+				By("verifying no additional bundle secret was created")
+				secretList := &corev1.SecretList{}
+				Expect(fakeClient.List(ctx, secretList, client.InNamespace(namespace), client.MatchingLabels{
+					"managed-by": "secrets-manager",
+					"bundle-for": name,
+				})).To(Succeed())
+				Expect(secretList.Items).To(HaveLen(1))
+
+				By("verifying internal store reflects the adopted bundle")
+				secretInfos, found := m.getFromStore(namespace, name)
+				Expect(found).To(BeTrue())
+				Expect(secretInfos.bundle.obj.Name).To(Equal(foreignBundle.Name))
+			})
 		})
 
 		Context("for certificate secrets", func() {
@@ -419,133 +1088,764 @@ var _ = Describe("Generate", func() {
 				))
 			})
 
+			It("should generate a new server secret when a custom subject field changes", func() {
+				By("generating new CA secret")
+				caSecret, err := m.Generate(ctx, caConfig)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, caSecret)
+
+				By("generating new server secret")
+				serverConfig.OrganizationalUnit = []string{"IT"}
+				serverSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, serverSecret)
+
+				By("changing custom subject field and generate again")
+				serverConfig.OrganizationalUnit = []string{"Security"}
+				newServerSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newServerSecret)
+
+				By("verifying a new secret was generated")
+				Expect(newServerSecret).NotTo(Equal(serverSecret))
+			})
+
+			It("should clamp a leaf's validity to a short-lived CA's expiry and reflect that in its labels", func() {
+				By("generating short-lived CA secret")
+				caConfig.Clock = fakeClock
+				caValidity := time.Hour
+				caConfig.Validity = &caValidity
+				caSecret, err := m.Generate(ctx, caConfig)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, caSecret)
+
+				By("generating server secret with a much longer requested validity")
+				serverConfig.Clock = fakeClock
+				serverValidity := 24 * time.Hour
+				serverConfig.Validity = &serverValidity
+				serverSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, serverSecret)
+
+				By("verifying the server secret's expiry was clamped to the CA's")
+				caNotAfter := fakeClock.Now().Add(caValidity)
+				Expect(serverSecret.Labels).To(HaveKeyWithValue("valid-until-time", strconv.FormatInt(caNotAfter.Unix(), 10)))
+			})
+
+			It("should set the next-renewal-time annotation to valid-until-time minus the renewal window", func() {
+				By("generating new CA secret")
+				caSecret, err := m.Generate(ctx, caConfig)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, caSecret)
+
+				By("generating short-lived server secret with a renewal window")
+				serverConfig.Clock = fakeClock
+				serverValidity := 2 * time.Hour
+				serverConfig.Validity = &serverValidity
+				renewalWindow := 30 * time.Minute
+				serverSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName), WithRenewalWindow(renewalWindow))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, serverSecret)
+
+				By("verifying the next-renewal-time annotation")
+				validUntilTime, err := strconv.ParseInt(serverSecret.Labels["valid-until-time"], 10, 64)
+				Expect(err).NotTo(HaveOccurred())
+				expectedNextRenewalTime := time.Unix(validUntilTime, 0).Add(-renewalWindow)
+				Expect(serverSecret.Annotations).To(HaveKeyWithValue("next-renewal-time", strconv.FormatInt(expectedNextRenewalTime.Unix(), 10)))
+
+				By("generating again and verifying the annotation is recomputed based on the manager's clock")
+				fakeClock.Step(time.Minute)
+				newServerSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName), WithRenewalWindow(renewalWindow), ForceRegenerate())
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newServerSecret)
+
+				newValidUntilTime, err := strconv.ParseInt(newServerSecret.Labels["valid-until-time"], 10, 64)
+				Expect(err).NotTo(HaveOccurred())
+				newExpectedNextRenewalTime := time.Unix(newValidUntilTime, 0).Add(-renewalWindow)
+				Expect(newServerSecret.Annotations).To(HaveKeyWithValue("next-renewal-time", strconv.FormatInt(newExpectedNextRenewalTime.Unix(), 10)))
+				Expect(newExpectedNextRenewalTime).NotTo(Equal(expectedNextRenewalTime))
+			})
+
 			It("should keep the same server cert even when the CA rotates", func() {
 				By("generating new CA secret")
 				caSecret, err := m.Generate(ctx, caConfig)
 				Expect(err).NotTo(HaveOccurred())
 				expectSecretWasCreated(ctx, fakeClient, caSecret)
 
-				By("generating new server secret")
-				serverSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName))
+				By("generating new server secret")
+				serverSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, serverSecret)
+
+				By("rotating CA")
+				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{name: time.Now()})
+				Expect(err).NotTo(HaveOccurred())
+				m = mgr.(*manager)
+
+				newCASecret, err := m.Generate(ctx, caConfig, Rotate(KeepOld))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newCASecret)
+
+				By("get or generate server secret")
+				newServerSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newServerSecret)
+
+				By("verifying server secret is still the same")
+				Expect(newServerSecret).To(Equal(withTypeMeta(serverSecret)))
+			})
+
+			It("should regenerate the server cert when the CA rotates and the 'UseCurrentCA' option is set", func() {
+				By("generating new CA secret")
+				caSecret, err := m.Generate(ctx, caConfig)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, caSecret)
+
+				By("generating new server secret")
+				serverSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName, UseCurrentCA))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, serverSecret)
+
+				By("rotating CA")
+				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{caName: time.Now()})
+				Expect(err).NotTo(HaveOccurred())
+				m = mgr.(*manager)
+
+				newCASecret, err := m.Generate(ctx, caConfig, Rotate(KeepOld))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newCASecret)
+
+				By("get or generate server secret")
+				newServerSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName, UseCurrentCA))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newServerSecret)
+
+				By("verifying server secret is changed")
+				Expect(newServerSecret).NotTo(Equal(serverSecret))
+			})
+
+			It("should maintain the 'signed-by-ca' annotations and update them when the CA rotates and the 'UseCurrentCA' option is set", func() {
+				By("generating new CA secret")
+				caSecret, err := m.Generate(ctx, caConfig)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, caSecret)
+
+				By("generating new server secret")
+				serverSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName, UseCurrentCA))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, serverSecret)
+
+				By("verifying server secret is annotated with the CA name and checksum")
+				Expect(serverSecret.Annotations).To(And(
+					HaveKeyWithValue("signed-by-ca", caName),
+					HaveKeyWithValue("signed-by-ca-checksum", serverSecret.Labels["checksum-of-signing-ca"]),
+				))
+				oldSigningCAChecksum := serverSecret.Annotations["signed-by-ca-checksum"]
+
+				By("rotating CA")
+				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{caName: time.Now()})
+				Expect(err).NotTo(HaveOccurred())
+				m = mgr.(*manager)
+
+				newCASecret, err := m.Generate(ctx, caConfig, Rotate(KeepOld))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newCASecret)
+
+				By("get or generate server secret")
+				newServerSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName, UseCurrentCA))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newServerSecret)
+
+				By("verifying server secret is now annotated with the new CA's checksum")
+				Expect(newServerSecret.Annotations).To(And(
+					HaveKeyWithValue("signed-by-ca", caName),
+					HaveKeyWithValue("signed-by-ca-checksum", newServerSecret.Labels["checksum-of-signing-ca"]),
+				))
+				Expect(newServerSecret.Annotations["signed-by-ca-checksum"]).NotTo(Equal(oldSigningCAChecksum))
+			})
+
+			It("should include the given IP in the server cert's SANs when the 'WithServiceClusterIP' option is used", func() {
+				By("generating new CA secret")
+				caSecret, err := m.Generate(ctx, caConfig)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, caSecret)
+
+				By("generating new server secret without the option")
+				serverSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, serverSecret)
+
+				By("verifying the certificate does not contain any IP SANs")
+				cert, err := utils.DecodeCertificate(serverSecret.Data[secretutils.DataKeyCertificate])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cert.IPAddresses).To(BeEmpty())
+
+				By("generating another server secret with the option")
+				serviceClusterIP := net.ParseIP("10.0.0.1")
+				otherServerConfig := &secretutils.CertificateSecretConfig{
+					Name:                        "server2",
+					CommonName:                  "server2",
+					CertType:                    secretutils.ServerCert,
+					SkipPublishingCACertificate: true,
+				}
+				otherServerSecret, err := m.Generate(ctx, otherServerConfig, SignedByCA(caName), WithServiceClusterIP(serviceClusterIP))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, otherServerSecret)
+
+				By("verifying the certificate contains the service cluster IP as a SAN")
+				otherCert, err := utils.DecodeCertificate(otherServerSecret.Data[secretutils.DataKeyCertificate])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(otherCert.IPAddresses).To(HaveLen(1))
+				Expect(otherCert.IPAddresses[0].Equal(serviceClusterIP)).To(BeTrue())
+			})
+
+			It("should return an error when 'WithServiceClusterIP' is given a nil IP", func() {
+				_, err := m.Generate(ctx, serverConfig, WithServiceClusterIP(nil))
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("should add the loopback SANs to the server cert when the 'WithLoopbackSANs' option is used", func() {
+				By("generating new CA secret")
+				caSecret, err := m.Generate(ctx, caConfig)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, caSecret)
+
+				By("generating new server secret with the option")
+				secret, err := m.Generate(ctx, serverConfig, SignedByCA(caName), WithLoopbackSANs())
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				By("verifying the certificate contains the loopback DNS and IP SANs")
+				cert, err := utils.DecodeCertificate(secret.Data[secretutils.DataKeyCertificate])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cert.DNSNames).To(ContainElement("localhost"))
+				Expect(cert.IPAddresses).To(HaveLen(2))
+				Expect(cert.IPAddresses[0].Equal(net.IPv4(127, 0, 0, 1))).To(BeTrue())
+				Expect(cert.IPAddresses[1].Equal(net.IPv6loopback)).To(BeTrue())
+			})
+
+			It("should not duplicate a loopback SAN that was already explicitly configured", func() {
+				By("generating new CA secret")
+				caSecret, err := m.Generate(ctx, caConfig)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, caSecret)
+
+				By("generating new server secret with an explicit loopback DNS name and IP")
+				config := &secretutils.CertificateSecretConfig{
+					Name:                        "server2",
+					CommonName:                  "server2",
+					CertType:                    secretutils.ServerCert,
+					DNSNames:                    []string{"localhost"},
+					IPAddresses:                 []net.IP{net.IPv4(127, 0, 0, 1)},
+					SkipPublishingCACertificate: true,
+				}
+				secret, err := m.Generate(ctx, config, SignedByCA(caName), WithLoopbackSANs())
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, secret)
+
+				By("verifying the certificate contains each loopback SAN only once")
+				cert, err := utils.DecodeCertificate(secret.Data[secretutils.DataKeyCertificate])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cert.DNSNames).To(ConsistOf("localhost"))
+				Expect(cert.IPAddresses).To(HaveLen(2))
+				Expect(cert.IPAddresses[0].Equal(net.IPv4(127, 0, 0, 1))).To(BeTrue())
+				Expect(cert.IPAddresses[1].Equal(net.IPv6loopback)).To(BeTrue())
+			})
+
+			It("should sign the server cert with the current CA when the old CA's remaining validity is shorter than the requested certificate validity", func() {
+				By("generating new CA secret")
+				caConfig.Clock = fakeClock
+				caSecret, err := m.Generate(ctx, caConfig)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, caSecret)
+
+				By("advancing the clock close to the CA's expiration")
+				fakeClock.Step(10*365*24*time.Hour - time.Hour)
+
+				By("rotating CA")
+				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{caName: time.Now()})
+				Expect(err).NotTo(HaveOccurred())
+				m = mgr.(*manager)
+
+				newCASecret, err := m.Generate(ctx, caConfig, Rotate(KeepOld))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newCASecret)
+
+				By("generating server secret with a validity that outlives the old CA")
+				serverSecret, err := m.Generate(ctx, serverConfig, Validity(30*24*time.Hour), SignedByCA(caName))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, serverSecret)
+
+				By("verifying the server cert was signed by the current (new) CA")
+				newCACert, err := utils.DecodeCertificate(newCASecret.Data[secretutils.DataKeyCertificateCA])
+				Expect(err).NotTo(HaveOccurred())
+
+				serverCert, err := utils.DecodeCertificate(serverSecret.Data[secretutils.DataKeyCertificate])
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(serverCert.CheckSignatureFrom(newCACert)).To(Succeed())
+			})
+
+			It("should regenerate the client cert when the CA rotates", func() {
+				By("generating new CA secret")
+				caSecret, err := m.Generate(ctx, caConfig)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, caSecret)
+
+				By("generating new client secret")
+				clientSecret, err := m.Generate(ctx, clientConfig, SignedByCA(caName))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, clientSecret)
+
+				By("rotating CA")
+				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{caName: time.Now()})
+				Expect(err).NotTo(HaveOccurred())
+				m = mgr.(*manager)
+
+				newCASecret, err := m.Generate(ctx, caConfig, Rotate(KeepOld))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newCASecret)
+
+				By("get or generate client secret")
+				newClientSecret, err := m.Generate(ctx, clientConfig, SignedByCA(caName))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newClientSecret)
+
+				By("verifying client secret is changed")
+				Expect(newClientSecret).NotTo(Equal(clientSecret))
+			})
+
+			It("should embed the CA bundle in the leaf secret when IncludeCABundle is used, kept current after a CA rotation", func() {
+				By("generating new CA secret")
+				caSecret, err := m.Generate(ctx, caConfig)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, caSecret)
+
+				By("generating new server secret")
+				serverSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName, UseCurrentCA), IncludeCABundle())
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, serverSecret)
+
+				By("verifying the server secret's CA data matches the CA bundle secret")
+				caBundleSecret, found := m.Get(caName, Bundle)
+				Expect(found).To(BeTrue())
+				Expect(serverSecret.Data[secretutils.DataKeyCertificateCA]).To(Equal(caBundleSecret.Data[secretutils.DataKeyCertificateBundle]))
+
+				By("rotating CA")
+				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{caName: time.Now()})
+				Expect(err).NotTo(HaveOccurred())
+				m = mgr.(*manager)
+
+				newCASecret, err := m.Generate(ctx, caConfig, Rotate(KeepOld))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newCASecret)
+
+				By("regenerating server secret since UseCurrentCA is set")
+				newServerSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName, UseCurrentCA), IncludeCABundle())
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newServerSecret)
+				Expect(newServerSecret).NotTo(Equal(serverSecret))
+
+				By("verifying the new server secret's CA data now matches the rotated CA bundle")
+				newCABundleSecret, found := m.Get(caName, Bundle)
+				Expect(found).To(BeTrue())
+				Expect(newCABundleSecret.Data[secretutils.DataKeyCertificateBundle]).NotTo(Equal(caBundleSecret.Data[secretutils.DataKeyCertificateBundle]))
+				Expect(newServerSecret.Data[secretutils.DataKeyCertificateCA]).To(Equal(newCABundleSecret.Data[secretutils.DataKeyCertificateBundle]))
+			})
+
+			It("should return an error when SignedByCA references a tracked secret whose certificate is not a CA", func() {
+				nonCACert, err := (&secretutils.CertificateSecretConfig{
+					Name:       "not-a-ca",
+					CommonName: "not-a-ca",
+					CertType:   secretutils.ServerCert,
+				}).GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				By("seeding the internal store with a non-CA secret stored under the CA data keys")
+				Expect(fakeClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "not-a-ca",
+						Namespace: namespace,
+						Labels: map[string]string{
+							LabelKeyName:            "not-a-ca",
+							LabelKeyManagedBy:       LabelValueSecretsManager,
+							LabelKeyManagerIdentity: identity,
+						},
+					},
+					Data: nonCACert.SecretData(),
+				})).To(Succeed())
+				Expect(m.Refresh(ctx)).To(Succeed())
+
+				_, err = m.Generate(ctx, clientConfig, SignedByCA("not-a-ca"))
+				Expect(err).To(MatchError(ContainSubstring("is not a CA certificate")))
+			})
+
+			It("should also accept ControlPlaneSecretConfigs", func() {
+				By("generating new CA secret")
+				caSecret, err := m.Generate(ctx, caConfig)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, caSecret)
+
+				By("generating new control plane secret")
+				serverConfig.Clock = fakeClock
+				serverConfig.Validity = utils.DurationPtr(1337 * time.Minute)
+				controlPlaneSecretConfig := &secretutils.ControlPlaneSecretConfig{
+					Name:                    "control-plane-secret",
+					CertificateSecretConfig: serverConfig,
+					KubeConfigRequests: []secretutils.KubeConfigRequest{{
+						ClusterName:   namespace,
+						APIServerHost: "some-host",
+					}},
+				}
+
+				serverSecret, err := m.Generate(ctx, controlPlaneSecretConfig, SignedByCA(caName))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, serverSecret)
+
+				By("verifying labels")
+				Expect(serverSecret.Labels).To(And(
+					HaveKeyWithValue("issued-at-time", strconv.FormatInt(fakeClock.Now().Unix(), 10)),
+					HaveKeyWithValue("valid-until-time", strconv.FormatInt(fakeClock.Now().Add(*serverConfig.Validity).Unix(), 10)),
+				))
+			})
+
+			It("should embed the CA bundle in the generated kubeconfig when IncludeCABundle is used, kept current after a CA rotation", func() {
+				By("generating new CA secret")
+				caSecret, err := m.Generate(ctx, caConfig)
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, caSecret)
+
+				By("generating new control plane secret")
+				controlPlaneSecretConfig := &secretutils.ControlPlaneSecretConfig{
+					Name:                    "control-plane-secret",
+					CertificateSecretConfig: serverConfig,
+					KubeConfigRequests: []secretutils.KubeConfigRequest{{
+						ClusterName:   namespace,
+						APIServerHost: "some-host",
+					}},
+				}
+
+				serverSecret, err := m.Generate(ctx, controlPlaneSecretConfig, SignedByCA(caName, UseCurrentCA), IncludeCABundle())
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, serverSecret)
+
+				By("verifying the kubeconfig's certificate-authority-data matches the CA bundle secret")
+				caBundleSecret, found := m.Get(caName, Bundle)
+				Expect(found).To(BeTrue())
+
+				kubeconfig := &clientcmdv1.Config{}
+				Expect(yaml.Unmarshal(serverSecret.Data[secretutils.DataKeyKubeconfig], kubeconfig)).To(Succeed())
+				Expect(kubeconfig.Clusters[0].Cluster.CertificateAuthorityData).To(Equal(caBundleSecret.Data[secretutils.DataKeyCertificateBundle]))
+
+				By("rotating CA")
+				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{caName: time.Now()})
+				Expect(err).NotTo(HaveOccurred())
+				m = mgr.(*manager)
+
+				newCASecret, err := m.Generate(ctx, caConfig, Rotate(KeepOld))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newCASecret)
+
+				By("regenerating control plane secret since UseCurrentCA is set")
+				newServerSecret, err := m.Generate(ctx, controlPlaneSecretConfig, SignedByCA(caName, UseCurrentCA), IncludeCABundle())
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, newServerSecret)
+				Expect(newServerSecret).NotTo(Equal(serverSecret))
+
+				By("verifying the regenerated kubeconfig's certificate-authority-data now contains both CAs")
+				newCABundleSecret, found := m.Get(caName, Bundle)
+				Expect(found).To(BeTrue())
+				Expect(newCABundleSecret.Data[secretutils.DataKeyCertificateBundle]).NotTo(Equal(caBundleSecret.Data[secretutils.DataKeyCertificateBundle]))
+				Expect(newCABundleSecret.Data[secretutils.DataKeyCertificateBundle]).To(ContainSubstring(string(caSecret.Data[secretutils.DataKeyCertificateCA])))
+				Expect(newCABundleSecret.Data[secretutils.DataKeyCertificateBundle]).To(ContainSubstring(string(newCASecret.Data[secretutils.DataKeyCertificateCA])))
+
+				newKubeconfig := &clientcmdv1.Config{}
+				Expect(yaml.Unmarshal(newServerSecret.Data[secretutils.DataKeyKubeconfig], newKubeconfig)).To(Succeed())
+				Expect(newKubeconfig.Clusters[0].Cluster.CertificateAuthorityData).To(Equal(newCABundleSecret.Data[secretutils.DataKeyCertificateBundle]))
+				Expect(newKubeconfig.Clusters[0].Cluster.CertificateAuthorityData).To(ContainSubstring(string(caSecret.Data[secretutils.DataKeyCertificateCA])))
+				Expect(newKubeconfig.Clusters[0].Cluster.CertificateAuthorityData).To(ContainSubstring(string(newCASecret.Data[secretutils.DataKeyCertificateCA])))
+			})
+
+			It("should correctly maintain lifetime labels for ControlPlaneSecretConfigs w/o certificate secret configs", func() {
+				By("generating new control plane secret")
+				cpSecret, err := m.Generate(ctx, &secretutils.ControlPlaneSecretConfig{Name: "control-plane-secret"})
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, cpSecret)
+
+				By("verifying labels")
+				Expect(cpSecret.Labels).To(And(
+					HaveKeyWithValue("issued-at-time", strconv.FormatInt(fakeClock.Now().Unix(), 10)),
+					Not(HaveKey("valid-until-time")),
+				))
+			})
+		})
+
+		Context("SignedByExternalCA option", func() {
+			var (
+				externalCARef  corev1.SecretReference
+				serverConfig   *secretutils.CertificateSecretConfig
+				externalCACert *secretutils.Certificate
+			)
+
+			BeforeEach(func() {
+				externalCARef = corev1.SecretReference{Namespace: "other-namespace", Name: "external-ca"}
+
+				var err error
+				externalCACert, err = (&secretutils.CertificateSecretConfig{
+					Name:       externalCARef.Name,
+					CommonName: externalCARef.Name,
+					CertType:   secretutils.CACert,
+				}).GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: externalCARef.Name, Namespace: externalCARef.Namespace},
+					Data:       externalCACert.SecretData(),
+				})).To(Succeed())
+
+				serverConfig = &secretutils.CertificateSecretConfig{
+					Name:                        "server",
+					CommonName:                  "server",
+					CertType:                    secretutils.ServerCert,
+					SkipPublishingCACertificate: true,
+				}
+			})
+
+			It("should sign the leaf certificate with the externally referenced CA without requiring it in the internal store", func() {
+				serverSecret, err := m.Generate(ctx, serverConfig, SignedByExternalCA(externalCARef))
+				Expect(err).NotTo(HaveOccurred())
+				expectSecretWasCreated(ctx, fakeClient, serverSecret)
+
+				leaf, err := secretutils.LoadCertificateFromSecret("server", serverSecret.Data)
+				Expect(err).NotTo(HaveOccurred())
+
+				// externalCACert.Certificate is the in-memory template used to sign the CA's own certificate and is
+				// missing fields (e.g. PublicKeyAlgorithm) only populated when parsing a certificate from DER, so
+				// re-parse the CA's PEM to obtain a certificate object usable for signature verification.
+				parsedCACert, err := utils.DecodeCertificate(externalCACert.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(leaf.Certificate.CheckSignatureFrom(parsedCACert)).To(Succeed())
+			})
+
+			It("should fail if the referenced secret does not exist", func() {
+				_, err := m.Generate(ctx, serverConfig, SignedByExternalCA(corev1.SecretReference{Namespace: "other-namespace", Name: "does-not-exist"}))
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("should fail if the referenced secret's certificate is not a CA", func() {
+				nonCACert, err := (&secretutils.CertificateSecretConfig{
+					Name:                        "not-a-ca",
+					CommonName:                  "not-a-ca",
+					CertType:                    secretutils.ServerCert,
+					SigningCA:                   externalCACert,
+					SkipPublishingCACertificate: true,
+				}).GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+
+				// Store it under the CA data keys (rather than the usual tls.crt/tls.key) so that only the IsCA
+				// validation, and not the "missing data keys" check, is exercised.
+				Expect(fakeClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "not-a-ca", Namespace: "other-namespace"},
+					Data: map[string][]byte{
+						secretutils.DataKeyCertificateCA: nonCACert.CertificatePEM,
+						secretutils.DataKeyPrivateKeyCA:  nonCACert.PrivateKeyPEM,
+					},
+				})).To(Succeed())
+
+				_, err = m.Generate(ctx, serverConfig, SignedByExternalCA(corev1.SecretReference{Namespace: "other-namespace", Name: "not-a-ca"}))
+				Expect(err).To(MatchError(ContainSubstring("is not a CA certificate")))
+			})
+
+			It("should fail if the referenced secret does not contain a CA certificate/key", func() {
+				Expect(fakeClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "incomplete-ca", Namespace: "other-namespace"},
+					Data:       map[string][]byte{"foo": []byte("bar")},
+				})).To(Succeed())
+
+				_, err := m.Generate(ctx, serverConfig, SignedByExternalCA(corev1.SecretReference{Namespace: "other-namespace", Name: "incomplete-ca"}))
+				Expect(err).To(MatchError(ContainSubstring("does not contain both")))
+			})
+		})
+
+		Context("ForceRegenerate option", func() {
+			var config *secretutils.CertificateSecretConfig
+
+			BeforeEach(func() {
+				config = &secretutils.CertificateSecretConfig{
+					Name:       name,
+					CommonName: name,
+					CertType:   secretutils.CACert,
+				}
+			})
+
+			It("should mint fresh material for an unchanged config and keep the secret's name", func() {
+				By("generating new secret")
+				secret, err := m.Generate(ctx, config)
 				Expect(err).NotTo(HaveOccurred())
-				expectSecretWasCreated(ctx, fakeClient, serverSecret)
+				expectSecretWasCreated(ctx, fakeClient, secret)
 
-				By("rotating CA")
-				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{name: time.Now()})
+				By("regenerating with an unchanged config and ForceRegenerate")
+				newSecret, err := m.Generate(ctx, config, ForceRegenerate())
 				Expect(err).NotTo(HaveOccurred())
-				m = mgr.(*manager)
+				Expect(newSecret.Name).To(Equal(secret.Name))
+				Expect(newSecret.Data).NotTo(Equal(secret.Data))
 
-				newCASecret, err := m.Generate(ctx, caConfig, Rotate(KeepOld))
+				By("verifying the secret was updated in the cluster rather than recreated")
+				foundSecret := &corev1.Secret{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), foundSecret)).To(Succeed())
+				Expect(foundSecret.Data).To(Equal(newSecret.Data))
+			})
+
+			It("should keep the overwritten material available as the old secret if RotationStrategy is KeepOld", func() {
+				By("generating new secret")
+				secret, err := m.Generate(ctx, config)
 				Expect(err).NotTo(HaveOccurred())
-				expectSecretWasCreated(ctx, fakeClient, newCASecret)
+				expectSecretWasCreated(ctx, fakeClient, secret)
 
-				By("get or generate server secret")
-				newServerSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName))
+				By("regenerating with an unchanged config, ForceRegenerate and KeepOld")
+				newSecret, err := m.Generate(ctx, config, ForceRegenerate(), Rotate(KeepOld))
 				Expect(err).NotTo(HaveOccurred())
-				expectSecretWasCreated(ctx, fakeClient, newServerSecret)
+				Expect(newSecret.Name).To(Equal(secret.Name))
+				Expect(newSecret.Data).NotTo(Equal(secret.Data))
 
-				By("verifying server secret is still the same")
-				Expect(newServerSecret).To(Equal(withTypeMeta(serverSecret)))
+				By("verifying internal store reflects changes")
+				secretInfos, found := m.getFromStore(namespace, name)
+				Expect(found).To(BeTrue())
+				Expect(secretInfos.current.obj.Data).To(Equal(newSecret.Data))
+				Expect(secretInfos.old).NotTo(BeNil())
+				Expect(secretInfos.old.obj.Data).To(Equal(secret.Data))
 			})
 
-			It("should regenerate the server cert when the CA rotates and the 'UseCurrentCA' option is set", func() {
-				By("generating new CA secret")
-				caSecret, err := m.Generate(ctx, caConfig)
+			It("should not keep the overwritten material as the old secret if RotationStrategy is not KeepOld", func() {
+				By("generating new secret")
+				secret, err := m.Generate(ctx, config)
 				Expect(err).NotTo(HaveOccurred())
-				expectSecretWasCreated(ctx, fakeClient, caSecret)
+				expectSecretWasCreated(ctx, fakeClient, secret)
 
-				By("generating new server secret")
-				serverSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName, UseCurrentCA))
+				By("regenerating with ForceRegenerate but without KeepOld")
+				_, err = m.Generate(ctx, config, ForceRegenerate())
 				Expect(err).NotTo(HaveOccurred())
-				expectSecretWasCreated(ctx, fakeClient, serverSecret)
 
-				By("rotating CA")
-				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{caName: time.Now()})
-				Expect(err).NotTo(HaveOccurred())
-				m = mgr.(*manager)
+				By("verifying internal store reflects changes")
+				secretInfos, found := m.getFromStore(namespace, name)
+				Expect(found).To(BeTrue())
+				Expect(secretInfos.old).To(BeNil())
+			})
 
-				newCASecret, err := m.Generate(ctx, caConfig, Rotate(KeepOld))
+			It("should invoke the OnRotation callback with RotationReasonForceRegenerate", func() {
+				secret, err := m.Generate(ctx, config)
 				Expect(err).NotTo(HaveOccurred())
-				expectSecretWasCreated(ctx, fakeClient, newCASecret)
+				expectSecretWasCreated(ctx, fakeClient, secret)
 
-				By("get or generate server secret")
-				newServerSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName, UseCurrentCA))
+				var reasons []RotationReason
+				_, err = m.Generate(ctx, config, ForceRegenerate(), OnRotation(func(reason RotationReason) {
+					reasons = append(reasons, reason)
+				}))
 				Expect(err).NotTo(HaveOccurred())
-				expectSecretWasCreated(ctx, fakeClient, newServerSecret)
-
-				By("verifying server secret is changed")
-				Expect(newServerSecret).NotTo(Equal(serverSecret))
+				Expect(reasons).To(ConsistOf(RotationReasonForceRegenerate))
 			})
+		})
 
-			It("should regenerate the client cert when the CA rotates", func() {
-				By("generating new CA secret")
-				caSecret, err := m.Generate(ctx, caConfig)
-				Expect(err).NotTo(HaveOccurred())
-				expectSecretWasCreated(ctx, fakeClient, caSecret)
+		Context("WithClock option", func() {
+			var referenceTime = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
 
-				By("generating new client secret")
-				clientSecret, err := m.Generate(ctx, clientConfig, SignedByCA(caName))
+			It("should use the given clock for the issued-at-time label of a basic auth secret", func() {
+				config := &secretutils.BasicAuthSecretConfig{
+					Name:           name,
+					Format:         secretutils.BasicAuthFormatNormal,
+					Username:       "admin",
+					PasswordLength: 32,
+				}
+
+				secret, err := m.Generate(ctx, config, WithClock(clock.NewFakeClock(referenceTime)))
 				Expect(err).NotTo(HaveOccurred())
-				expectSecretWasCreated(ctx, fakeClient, clientSecret)
+				Expect(secret.Labels).To(HaveKeyWithValue(LabelKeyIssuedAtTime, unixTime(referenceTime)))
+			})
 
-				By("rotating CA")
-				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{caName: time.Now()})
+			It("should use the given clock for a certificate's NotBefore/NotAfter, taking precedence over the manager's own clock", func() {
+				config := &secretutils.CertificateSecretConfig{
+					Name:       name,
+					CommonName: name,
+					CertType:   secretutils.CACert,
+				}
+
+				secret, err := m.Generate(ctx, config, WithClock(clock.NewFakeClock(referenceTime)))
 				Expect(err).NotTo(HaveOccurred())
-				m = mgr.(*manager)
 
-				newCASecret, err := m.Generate(ctx, caConfig, Rotate(KeepOld))
+				certificate, err := utils.DecodeCertificate(secret.Data[secretutils.DataKeyCertificateCA])
 				Expect(err).NotTo(HaveOccurred())
-				expectSecretWasCreated(ctx, fakeClient, newCASecret)
+				Expect(certificate.NotBefore.UTC()).To(Equal(referenceTime))
+				Expect(secret.Labels).To(HaveKeyWithValue(LabelKeyIssuedAtTime, unixTime(referenceTime)))
+			})
+		})
 
-				By("get or generate client secret")
-				newClientSecret, err := m.Generate(ctx, clientConfig, SignedByCA(caName))
+		Context("WithStableNameAlias option", func() {
+			It("should maintain a stable-named alias Secret mirroring the checksum-named secret after a regeneration", func() {
+				config := &secretutils.BasicAuthSecretConfig{
+					Name:           name,
+					Format:         secretutils.BasicAuthFormatNormal,
+					Username:       "admin",
+					PasswordLength: 32,
+				}
+
+				By("generating new secret")
+				secret, err := m.Generate(ctx, config, WithStableNameAlias())
 				Expect(err).NotTo(HaveOccurred())
-				expectSecretWasCreated(ctx, fakeClient, newClientSecret)
+				expectSecretWasCreated(ctx, fakeClient, secret)
 
-				By("verifying client secret is changed")
-				Expect(newClientSecret).NotTo(Equal(clientSecret))
-			})
+				By("verifying the alias Secret was created alongside the checksum-named secret")
+				alias := &corev1.Secret{}
+				Expect(fakeClient.Get(ctx, kutil.Key(namespace, name), alias)).To(Succeed())
+				Expect(alias.Labels).To(HaveKeyWithValue(LabelKeyAliasFor, name))
+				Expect(alias.Labels).NotTo(HaveKey(LabelKeyManagedBy))
+				Expect(alias.Data).To(Equal(secret.Data))
 
-			It("should also accept ControlPlaneSecretConfigs", func() {
-				By("generating new CA secret")
-				caSecret, err := m.Generate(ctx, caConfig)
+				By("regenerating the secret with ForceRegenerate")
+				newSecret, err := m.Generate(ctx, config, WithStableNameAlias(), ForceRegenerate())
 				Expect(err).NotTo(HaveOccurred())
-				expectSecretWasCreated(ctx, fakeClient, caSecret)
+				Expect(newSecret.Name).To(Equal(secret.Name))
+				Expect(newSecret.Data).NotTo(Equal(secret.Data))
 
-				By("generating new control plane secret")
-				serverConfig.Clock = fakeClock
-				serverConfig.Validity = utils.DurationPtr(1337 * time.Minute)
-				controlPlaneSecretConfig := &secretutils.ControlPlaneSecretConfig{
-					Name:                    "control-plane-secret",
-					CertificateSecretConfig: serverConfig,
-					KubeConfigRequests: []secretutils.KubeConfigRequest{{
-						ClusterName:   namespace,
-						APIServerHost: "some-host",
-					}},
+				By("verifying the alias Secret was updated to mirror the newest checksum-named secret")
+				Expect(fakeClient.Get(ctx, kutil.Key(namespace, name), alias)).To(Succeed())
+				Expect(alias.Data).To(Equal(newSecret.Data))
+			})
+		})
+
+		Context("SerialNumberFunc", func() {
+			It("should use DefaultSerialNumberFunc by default", func() {
+				config := &secretutils.CertificateSecretConfig{
+					Name:       name,
+					CommonName: name,
+					CertType:   secretutils.CACert,
 				}
 
-				serverSecret, err := m.Generate(ctx, controlPlaneSecretConfig, SignedByCA(caName))
+				secret, err := m.Generate(ctx, config)
 				Expect(err).NotTo(HaveOccurred())
-				expectSecretWasCreated(ctx, fakeClient, serverSecret)
 
-				By("verifying labels")
-				Expect(serverSecret.Labels).To(And(
-					HaveKeyWithValue("issued-at-time", strconv.FormatInt(fakeClock.Now().Unix(), 10)),
-					HaveKeyWithValue("valid-until-time", strconv.FormatInt(fakeClock.Now().Add(*serverConfig.Validity).Unix(), 10)),
-				))
+				certificate, err := utils.DecodeCertificate(secret.Data[secretutils.DataKeyCertificateCA])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(certificate.SerialNumber).NotTo(BeNil())
 			})
 
-			It("should correctly maintain lifetime labels for ControlPlaneSecretConfigs w/o certificate secret configs", func() {
-				By("generating new control plane secret")
-				cpSecret, err := m.Generate(ctx, &secretutils.ControlPlaneSecretConfig{Name: "control-plane-secret"})
+			It("should issue the certificate with the serial number returned by the manager's configured SerialNumberFunc", func() {
+				DeferCleanup(test.WithVar(&DefaultSerialNumberFunc, SerialNumberFunc(func() (*big.Int, error) { return big.NewInt(1234), nil })))
+
+				mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
 				Expect(err).NotTo(HaveOccurred())
-				expectSecretWasCreated(ctx, fakeClient, cpSecret)
 
-				By("verifying labels")
-				Expect(cpSecret.Labels).To(And(
-					HaveKeyWithValue("issued-at-time", strconv.FormatInt(fakeClock.Now().Unix(), 10)),
-					Not(HaveKey("valid-until-time")),
-				))
+				config := &secretutils.CertificateSecretConfig{
+					Name:       name,
+					CommonName: name,
+					CertType:   secretutils.CACert,
+				}
+
+				secret, err := mgr.Generate(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+
+				certificate, err := utils.DecodeCertificate(secret.Data[secretutils.DataKeyCertificateCA])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(certificate.SerialNumber).To(Equal(big.NewInt(1234)))
 			})
 		})
 
@@ -607,6 +1907,51 @@ resources:
 					Expect(secret.Data["key"]).To(Equal(oldKey))
 					Expect(secret.Data["secret"]).To(Equal(oldSecret))
 				})
+
+				It("should retain previously used keys (newest first) across rotations, bounded by MaxKeys", func() {
+					DeferCleanup(test.WithVar(&secretutils.Clock, fakeClock))
+
+					newConfig := func() *secretutils.ETCDEncryptionKeySecretConfig {
+						return &secretutils.ETCDEncryptionKeySecretConfig{
+							Name:         config.Name,
+							SecretLength: config.SecretLength,
+						}
+					}
+
+					rotate := func() *corev1.Secret {
+						fakeClock.Step(time.Second)
+						m.lastRotationInitiationTimes[config.Name] = unixTime(fakeClock.Now())
+
+						secret, err := m.Generate(ctx, newConfig(), Rotate(KeepOld))
+						Expect(err).NotTo(HaveOccurred())
+						return secret
+					}
+
+					By("generating the initial secret")
+					firstSecret, err := m.Generate(ctx, newConfig(), Rotate(KeepOld))
+					Expect(err).NotTo(HaveOccurred())
+					firstKey, firstKeySecret := firstSecret.Data[secretutils.DataKeyEncryptionKeyName], firstSecret.Data[secretutils.DataKeyEncryptionSecret]
+					Expect(firstSecret.Data).NotTo(HaveKey(secretutils.DataKeyRetainedEncryptionKeysCSV))
+
+					By("rotating a first time")
+					secondSecret := rotate()
+					secondKey, secondKeySecret := secondSecret.Data[secretutils.DataKeyEncryptionKeyName], secondSecret.Data[secretutils.DataKeyEncryptionSecret]
+					Expect(secondKey).NotTo(Equal(firstKey))
+
+					retainedAfterSecond, err := secretutils.LoadRetainedEncryptionKeysFromCSV(secondSecret.Data[secretutils.DataKeyRetainedEncryptionKeysCSV])
+					Expect(err).NotTo(HaveOccurred())
+					Expect(retainedAfterSecond).To(Equal([]secretutils.ETCDEncryptionKeyEntry{{Key: string(firstKey), Secret: string(firstKeySecret)}}))
+
+					By("rotating a second time")
+					thirdSecret := rotate()
+					thirdKey := thirdSecret.Data[secretutils.DataKeyEncryptionKeyName]
+					Expect(thirdKey).NotTo(Equal(secondKey))
+
+					By("verifying the default MaxKeys bounds retention to the most recently superseded key")
+					retainedAfterThird, err := secretutils.LoadRetainedEncryptionKeysFromCSV(thirdSecret.Data[secretutils.DataKeyRetainedEncryptionKeysCSV])
+					Expect(err).NotTo(HaveOccurred())
+					Expect(retainedAfterThird).To(Equal([]secretutils.ETCDEncryptionKeyEntry{{Key: string(secondKey), Secret: string(secondKeySecret)}}))
+				})
 			})
 
 			Context("kube-apiserver basic auth", func() {
@@ -978,6 +2323,46 @@ resources:
 					Expect(secret.Data).To(Equal(oldData))
 				})
 
+				It("should ignore the legacy secret and generate a fresh one when AdoptExisting doesn't list it", func() {
+					By("creating existing secret with old password")
+					existingSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "ssh-keypair",
+							Namespace: namespace,
+						},
+						Type: corev1.SecretTypeOpaque,
+						Data: oldData,
+					}
+					Expect(fakeClient.Create(ctx, existingSecret)).To(Succeed())
+
+					By("generating secret with AdoptExisting opted into a different name")
+					secret, err := m.Generate(ctx, config, AdoptExisting("some-other-legacy-secret"))
+					Expect(err).NotTo(HaveOccurred())
+
+					By("verifying a fresh keypair was generated and the legacy secret was ignored")
+					Expect(secret.Data).NotTo(Equal(oldData))
+				})
+
+				It("should keep the existing ssh keypair when AdoptExisting explicitly lists it", func() {
+					By("creating existing secret with old password")
+					existingSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "ssh-keypair",
+							Namespace: namespace,
+						},
+						Type: corev1.SecretTypeOpaque,
+						Data: oldData,
+					}
+					Expect(fakeClient.Create(ctx, existingSecret)).To(Succeed())
+
+					By("generating secret with AdoptExisting opted into this name")
+					secret, err := m.Generate(ctx, config, AdoptExisting("ssh-keypair"))
+					Expect(err).NotTo(HaveOccurred())
+
+					By("verifying old password was kept")
+					Expect(secret.Data).To(Equal(oldData))
+				})
+
 				It("should make the manager adopt the old ssh keypair if it exists", func() {
 					By("creating existing secret with old password")
 					existingSecret := &corev1.Secret{
@@ -1014,6 +2399,45 @@ resources:
 						"persist":                       "true",
 						"last-rotation-initiation-time": "",
 					}))
+
+					By("verifying primary ssh keypair was also adopted")
+					Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(existingSecret), existingSecret)).To(Succeed())
+					Expect(existingSecret.Immutable).To(PointTo(BeTrue()))
+					Expect(existingSecret.Labels).To(Equal(map[string]string{
+						"name":                          "ssh-keypair",
+						"managed-by":                    "secrets-manager",
+						"manager-identity":              "test",
+						"persist":                       "true",
+						"last-rotation-initiation-time": "",
+					}))
+				})
+
+				It("should relabel and immutabilize the primary ssh keypair even if no '.old' secret exists", func() {
+					By("creating existing secret with old password")
+					existingSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "ssh-keypair",
+							Namespace: namespace,
+						},
+						Type: corev1.SecretTypeOpaque,
+						Data: oldData,
+					}
+					Expect(fakeClient.Create(ctx, existingSecret)).To(Succeed())
+
+					By("generating secret")
+					_, err := m.Generate(ctx, config)
+					Expect(err).NotTo(HaveOccurred())
+
+					By("verifying primary ssh keypair was adopted")
+					Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(existingSecret), existingSecret)).To(Succeed())
+					Expect(existingSecret.Immutable).To(PointTo(BeTrue()))
+					Expect(existingSecret.Labels).To(Equal(map[string]string{
+						"name":                          "ssh-keypair",
+						"managed-by":                    "secrets-manager",
+						"manager-identity":              "test",
+						"persist":                       "true",
+						"last-rotation-initiation-time": "",
+					}))
 				})
 			})
 
@@ -1058,6 +2482,44 @@ resources:
 					By("verifying old password was kept")
 					Expect(secret.Data).To(Equal(oldData))
 				})
+
+				It("should include both keys in the JWKS document after a KeepOld rotation", func() {
+					// GenerateKey is faked package-wide to keep test fixtures stable; restore the real
+					// implementation here so that this rotation actually mints two distinct keys.
+					DeferCleanup(test.WithVar(&secretutils.GenerateKey, rsa.GenerateKey))
+
+					config.IncludeJWKS = true
+
+					By("generating initial secret")
+					firstSecret, err := m.Generate(ctx, config)
+					Expect(err).NotTo(HaveOccurred())
+					expectSecretWasCreated(ctx, fakeClient, firstSecret)
+					Expect(firstSecret.Data).To(HaveKey(secretutils.DataKeyServiceAccountJWKS))
+
+					firstJWKS := struct {
+						Keys []struct {
+							Kid string `json:"kid"`
+						} `json:"keys"`
+					}{}
+					Expect(json.Unmarshal(firstSecret.Data[secretutils.DataKeyServiceAccountJWKS], &firstJWKS)).To(Succeed())
+					Expect(firstJWKS.Keys).To(HaveLen(1))
+
+					By("rotating the key with the KeepOld strategy")
+					secondSecret, err := m.Generate(ctx, config, ForceRegenerate(), Rotate(KeepOld))
+					Expect(err).NotTo(HaveOccurred())
+					expectSecretWasCreated(ctx, fakeClient, secondSecret)
+
+					By("verifying the new JWKS document contains both keys")
+					secondJWKS := struct {
+						Keys []struct {
+							Kid string `json:"kid"`
+						} `json:"keys"`
+					}{}
+					Expect(json.Unmarshal(secondSecret.Data[secretutils.DataKeyServiceAccountJWKS], &secondJWKS)).To(Succeed())
+					Expect(secondJWKS.Keys).To(HaveLen(2))
+					Expect(secondJWKS.Keys[0].Kid).NotTo(Equal(secondJWKS.Keys[1].Kid))
+					Expect(secondJWKS.Keys).To(ContainElement(firstJWKS.Keys[0]))
+				})
 			})
 
 			Context("ca-client", func() {
@@ -1132,6 +2594,118 @@ FskcKs088h3kZh8sc8pG25SCwKdEXXh7ufO3aYtEbViSAQbqIixNVdRO
 			})
 		})
 	})
+
+	Describe("#ComputeSecretName", func() {
+		It("should return the name that Generate would produce for a basic auth secret", func() {
+			config := &secretutils.BasicAuthSecretConfig{
+				Name:           "basic-auth",
+				Format:         secretutils.BasicAuthFormatNormal,
+				Username:       "foo",
+				PasswordLength: 3,
+			}
+
+			computedName, err := m.ComputeSecretName(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			secret, err := m.Generate(ctx, config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(computedName).To(Equal(secret.Name))
+		})
+
+		It("should return the bare config name for a CA secret with IgnoreConfigChecksumForCASecretName", func() {
+			config := &secretutils.CertificateSecretConfig{
+				Name:       "ca",
+				CommonName: "ca",
+				CertType:   secretutils.CACert,
+			}
+
+			computedName, err := m.ComputeSecretName(config, IgnoreConfigChecksumForCASecretName())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(computedName).To(Equal("ca"))
+
+			secret, err := m.Generate(ctx, config, IgnoreConfigChecksumForCASecretName())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(computedName).To(Equal(secret.Name))
+		})
+
+		It("should account for the signing CA's checksum when SignedByCA is used", func() {
+			caConfig := &secretutils.CertificateSecretConfig{
+				Name:       "ca",
+				CommonName: "ca",
+				CertType:   secretutils.CACert,
+			}
+			_, err := m.Generate(ctx, caConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			serverConfig := &secretutils.CertificateSecretConfig{
+				Name:                        "server",
+				CommonName:                  "server",
+				CertType:                    secretutils.ServerCert,
+				SkipPublishingCACertificate: true,
+			}
+
+			computedName, err := m.ComputeSecretName(serverConfig, SignedByCA("ca"))
+			Expect(err).NotTo(HaveOccurred())
+
+			secret, err := m.Generate(ctx, serverConfig, SignedByCA("ca"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(computedName).To(Equal(secret.Name))
+		})
+
+		It("should not create or persist any secret", func() {
+			config := &secretutils.BasicAuthSecretConfig{
+				Name:           "basic-auth",
+				Format:         secretutils.BasicAuthFormatNormal,
+				Username:       "foo",
+				PasswordLength: 3,
+			}
+
+			_, err := m.ComputeSecretName(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			secretList := &corev1.SecretList{}
+			Expect(fakeClient.List(ctx, secretList, client.InNamespace(namespace))).To(Succeed())
+			Expect(secretList.Items).To(BeEmpty())
+		})
+
+		It("should return the pinned name when WithName is used", func() {
+			config := &secretutils.BasicAuthSecretConfig{
+				Name:           "basic-auth",
+				Format:         secretutils.BasicAuthFormatNormal,
+				Username:       "foo",
+				PasswordLength: 3,
+			}
+
+			computedName, err := m.ComputeSecretName(config, WithName("pinned-name"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(computedName).To(Equal("pinned-name"))
+		})
+	})
+
+	Describe("name prefix", func() {
+		It("should not collide on secret names when two managers with different prefixes share a namespace", func() {
+			config := &secretutils.BasicAuthSecretConfig{
+				Name:           "config",
+				Format:         secretutils.BasicAuthFormatNormal,
+				Username:       "foo",
+				PasswordLength: 3,
+			}
+
+			mgr1, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, "tenant1", nil, "tenant1-")
+			Expect(err).NotTo(HaveOccurred())
+			mgr2, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, "tenant2", nil, "tenant2-")
+			Expect(err).NotTo(HaveOccurred())
+
+			secret1, err := mgr1.Generate(ctx, config)
+			Expect(err).NotTo(HaveOccurred())
+			secret2, err := mgr2.Generate(ctx, config)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(secret1.Name).To(HavePrefix("tenant1-"))
+			Expect(secret2.Name).To(HavePrefix("tenant2-"))
+			Expect(secret1.Name).NotTo(Equal(secret2.Name))
+		})
+	})
 })
 
 func expectSecretWasCreated(ctx context.Context, fakeClient client.Client, secret *corev1.Secret) {
@@ -1152,3 +2726,69 @@ func withoutTypeMeta(obj *corev1.Secret) *corev1.Secret {
 	secret.TypeMeta = metav1.TypeMeta{}
 	return secret
 }
+
+// recordingLogEntry is a single Info call captured by a recordingLogSink.
+type recordingLogEntry struct {
+	level  int
+	msg    string
+	values map[string]interface{}
+}
+
+// recordingLogSink is a minimal logr.LogSink which records every Info call it receives, so that tests can assert on
+// the structured key-values emitted by the code under test.
+type recordingLogSink struct {
+	entries []recordingLogEntry
+}
+
+func (s *recordingLogSink) Init(logr.RuntimeInfo)                  {}
+func (s *recordingLogSink) Enabled(level int) bool                 { return true }
+func (s *recordingLogSink) WithName(string) logr.LogSink           { return s }
+func (s *recordingLogSink) WithValues(...interface{}) logr.LogSink { return s }
+func (s *recordingLogSink) Error(error, string, ...interface{})    {}
+
+func (s *recordingLogSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	values := map[string]interface{}{}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			continue
+		}
+		values[key] = keysAndValues[i+1]
+	}
+	s.entries = append(s.entries, recordingLogEntry{level: level, msg: msg, values: values})
+}
+
+// find returns the first recorded entry whose "action" value matches the given action.
+func (s *recordingLogSink) find(action string) (recordingLogEntry, bool) {
+	entries := s.findAll(action)
+	if len(entries) == 0 {
+		return recordingLogEntry{}, false
+	}
+	return entries[0], true
+}
+
+// findAll returns all recorded entries whose "action" value matches the given action, in recording order.
+func (s *recordingLogSink) findAll(action string) []recordingLogEntry {
+	var entries []recordingLogEntry
+	for _, entry := range s.entries {
+		if entry.values["action"] == action {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// conflictOncePatchClient returns a conflict error for the first Patch call and delegates to the wrapped client
+// for all subsequent calls, in order to exercise Generate's retry-on-conflict behavior.
+type conflictOncePatchClient struct {
+	client.Client
+	patchCalls int
+}
+
+func (c *conflictOncePatchClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.patchCalls++
+	if c.patchCalls == 1 {
+		return apierrors.NewConflict(schema.GroupResource{Resource: "secrets"}, obj.GetName(), fmt.Errorf("conflicting modification"))
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
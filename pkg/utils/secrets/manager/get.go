@@ -22,7 +22,12 @@ func (m *manager) Get(name string, opts ...GetOption) (*corev1.Secret, bool) {
 	options := &GetOptions{}
 	options.ApplyOptions(opts)
 
-	secrets, found := m.getFromStore(name)
+	namespace := m.namespace
+	if options.Namespace != "" {
+		namespace = options.Namespace
+	}
+
+	secrets, found := m.getFromStore(namespace, name)
 	if !found {
 		return nil, false
 	}
@@ -50,6 +55,36 @@ func (m *manager) Get(name string, opts ...GetOption) (*corev1.Secret, bool) {
 	}
 }
 
+// GetRetained returns the secrets retained for the secret with the given name by a KeepLastN rotation strategy,
+// newest first, not including the current secret. It is empty if the secret was never rotated with KeepLastN.
+func (m *manager) GetRetained(name string, opts ...GetOption) []*corev1.Secret {
+	options := &GetOptions{}
+	options.ApplyOptions(opts)
+
+	namespace := m.namespace
+	if options.Namespace != "" {
+		namespace = options.Namespace
+	}
+
+	secrets, found := m.getFromStore(namespace, name)
+	if !found {
+		return nil
+	}
+
+	retained := make([]*corev1.Secret, 0, len(secrets.retained))
+	for _, info := range secrets.retained {
+		retained = append(retained, info.obj)
+	}
+	return retained
+}
+
+// GetBundle returns the current bundle secret tracked for the secret with the given name. It is a convenience
+// wrapper around Get(name, Bundle) so that callers wiring bundle secrets into volume mounts don't need to know about
+// the generic Get/GetOption machinery or the bundle secret's deterministic "<name>-bundle" naming.
+func (m *manager) GetBundle(name string, opts ...GetOption) (*corev1.Secret, bool) {
+	return m.Get(name, append(opts, Bundle)...)
+}
+
 // GetOption is some configuration that modifies options for a Get request.
 type GetOption interface {
 	// ApplyToOptions applies this configuration to the given options.
@@ -61,6 +96,9 @@ type GetOptions struct {
 	// Class specifies whether which secret should be returned. By default, the bundle secret is returned. If there is
 	// no bundle secret then it falls back to the current secret.
 	Class *secretClass
+	// Namespace overrides the namespace to look up the secret in. If empty, the manager's default namespace (as
+	// passed to New) is used.
+	Namespace string
 }
 
 // ApplyOptions applies the given update options on these options, and then returns itself (for convenient chaining).
@@ -87,3 +125,17 @@ type classOption struct {
 func (c classOption) ApplyToOptions(options *GetOptions) {
 	options.Class = &c.class
 }
+
+// FromNamespace returns a function which sets the 'Namespace' field in the GetOptions, so that Get looks up the
+// secret generated for the given namespace instead of the manager's default namespace.
+func FromNamespace(namespace string) GetOption {
+	return namespaceOption{namespace: namespace}
+}
+
+type namespaceOption struct {
+	namespace string
+}
+
+func (n namespaceOption) ApplyToOptions(options *GetOptions) {
+	options.Namespace = n.namespace
+}
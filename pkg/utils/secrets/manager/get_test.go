@@ -40,7 +40,7 @@ var _ = Describe("Get", func() {
 		Context("bundle", func() {
 			It("should return an error since there is no bundle secret in the internal store", func() {
 				currentSecret := secretForClass(current)
-				Expect(m.addToStore(name, currentSecret, current)).To(Succeed())
+				Expect(m.addToStore("", name, currentSecret, current)).To(Succeed())
 
 				result, found := m.Get(name, Bundle)
 				Expect(found).To(BeFalse())
@@ -50,7 +50,7 @@ var _ = Describe("Get", func() {
 			secret := secretForClass(bundle)
 
 			It("should get the bundle secret from the internal store", func() {
-				Expect(m.addToStore(name, secret, bundle)).To(Succeed())
+				Expect(m.addToStore("", name, secret, bundle)).To(Succeed())
 
 				result, found := m.Get(name, Bundle)
 				Expect(found).To(BeTrue())
@@ -58,7 +58,7 @@ var _ = Describe("Get", func() {
 			})
 
 			It("should get the bundle secret from the internal store (w/o explicit option)", func() {
-				Expect(m.addToStore(name, secret, bundle)).To(Succeed())
+				Expect(m.addToStore("", name, secret, bundle)).To(Succeed())
 
 				result, found := m.Get(name)
 				Expect(found).To(BeTrue())
@@ -74,11 +74,11 @@ var _ = Describe("Get", func() {
 			)
 
 			BeforeEach(func() {
-				Expect(m.addToStore(name, currentSecret, current)).To(Succeed())
+				Expect(m.addToStore("", name, currentSecret, current)).To(Succeed())
 			})
 
 			It("should get the bundle secret from the internal store (default behaviour w/o options)", func() {
-				Expect(m.addToStore(name, bundleSecret, bundle)).To(Succeed())
+				Expect(m.addToStore("", name, bundleSecret, bundle)).To(Succeed())
 
 				result, found := m.Get(name)
 				Expect(found).To(BeTrue())
@@ -92,7 +92,7 @@ var _ = Describe("Get", func() {
 			})
 
 			It("should get the current secret from the internal store despite a bundle secret (w/ explicit option)", func() {
-				Expect(m.addToStore(name, bundleSecret, bundle)).To(Succeed())
+				Expect(m.addToStore("", name, bundleSecret, bundle)).To(Succeed())
 
 				result, found := m.Get(name, Current)
 				Expect(found).To(BeTrue())
@@ -103,7 +103,7 @@ var _ = Describe("Get", func() {
 		Context("old", func() {
 			It("should return an error since there is no old secret in the internal store", func() {
 				currentSecret := secretForClass(current)
-				Expect(m.addToStore(name, currentSecret, current)).To(Succeed())
+				Expect(m.addToStore("", name, currentSecret, current)).To(Succeed())
 
 				result, found := m.Get(name, Old)
 				Expect(found).To(BeFalse())
@@ -112,7 +112,7 @@ var _ = Describe("Get", func() {
 
 			It("should get the old secret from the internal store", func() {
 				oldSecret := secretForClass(old)
-				Expect(m.addToStore(name, oldSecret, old)).To(Succeed())
+				Expect(m.addToStore("", name, oldSecret, old)).To(Succeed())
 
 				result, found := m.Get(name, Old)
 				Expect(found).To(BeTrue())
@@ -120,6 +120,31 @@ var _ = Describe("Get", func() {
 			})
 		})
 	})
+
+	Describe("#GetBundle", func() {
+		It("should return the same bundle secret that Get(name, Bundle) finds", func() {
+			currentSecret := secretForClass(current)
+			bundleSecret := secretForClass(bundle)
+			Expect(m.addToStore("", "ca", currentSecret, current)).To(Succeed())
+			Expect(m.addToStore("", "ca", bundleSecret, bundle)).To(Succeed())
+
+			result, found := m.GetBundle("ca")
+			Expect(found).To(BeTrue())
+			Expect(result).To(Equal(bundleSecret))
+
+			viaGet, found := m.Get("ca", Bundle)
+			Expect(found).To(BeTrue())
+			Expect(result).To(Equal(viaGet))
+		})
+
+		It("should return false if there is no bundle secret for the name", func() {
+			Expect(m.addToStore("", "ca", secretForClass(current), current)).To(Succeed())
+
+			result, found := m.GetBundle("ca")
+			Expect(found).To(BeFalse())
+			Expect(result).To(BeNil())
+		})
+	})
 })
 
 func secretForClass(class secretClass) *corev1.Secret {
@@ -16,10 +16,12 @@ package manager
 
 import (
 	"context"
+	"time"
 
 	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
 
 	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Reader is part of the SecretsManager interface and allows retrieving secrets from a SecretsManager.
@@ -28,19 +30,119 @@ type Reader interface {
 	// If there is no bundle secret then it falls back to the current secret. Note that only those secrets are known
 	// which were detected or generated by prior Generate calls.
 	Get(string, ...GetOption) (*corev1.Secret, bool)
+
+	// GetBundle returns the current bundle secret for the secret with the given name. It is equivalent to calling
+	// Get(name, Bundle).
+	GetBundle(string, ...GetOption) (*corev1.Secret, bool)
+
+	// GetRetained returns the secrets retained for the secret with the given name by a KeepLastN rotation strategy,
+	// newest first, not including the current secret. It is empty if the secret was never rotated with KeepLastN.
+	GetRetained(string, ...GetOption) []*corev1.Secret
 }
 
 // Interface describes the methods for managing secrets.
 type Interface interface {
+	// Identity returns the identity this manager was created with.
+	Identity() string
+
+	// Namespace returns the namespace this manager was created for.
+	Namespace() string
+
+	// LabelSelector returns a client.MatchingLabelsSelector matching the secrets managed by this manager's identity,
+	// e.g. for listing them via a client.List call. By default, it matches every secret (current, old, and bundle)
+	// managed by this manager; use ForName and/or ForBundles to narrow it down.
+	LabelSelector(opts ...SelectorOption) client.MatchingLabelsSelector
+
 	// Generate generates a secret based on the provided configuration. If the secret for the provided configuration
 	// already exists then it is returned with re-generation. The function also automatically rotates/re-generates the
 	// secret only if necessary (e.g., when the config or the signing CA changes).
 	Generate(context.Context, secretutils.ConfigInterface, ...GenerateOption) (*corev1.Secret, error)
 
+	// ComputeSecretName returns the name a Generate call with the given configuration and options would produce,
+	// without creating or otherwise mutating any secret.
+	ComputeSecretName(secretutils.ConfigInterface, ...GenerateOption) (string, error)
+
 	Reader
 
 	// Cleanup deletes no longer required secrets. No longer required secrets are those still existing in the system
 	// which weren't detected by prior Generate calls. Consequently, only call Cleanup after you have executed Generate
 	// calls for all desired secrets.
 	Cleanup(context.Context) error
+
+	// CompleteRotation deletes the 'old' secret tracked for the secret with the given name, and clears it from the
+	// internal store, but only once at least gracePeriod has elapsed since the current secret took over. It is a
+	// no-op if there is no 'old' secret tracked for the given name; before the grace period has elapsed it only labels
+	// the current secret's rotation phase as 'completing'. Call
+	// this once callers of a KeepOld-rotated secret have had a chance to migrate to the current secret.
+	CompleteRotation(ctx context.Context, name string, gracePeriod time.Duration) error
+
+	// WaitForCleanup reports whether the 'old' secret tracked for the secret with the given name has been deleted, and
+	// deletes it if migrated reports that every consumer has adopted the current secret. It is a no-op, returning
+	// false, if there is no 'old' secret tracked for the given name. Call this, like CompleteRotation, once a
+	// KeepOld-rotated secret's consumers should have had a chance to migrate to the current secret, but gate on an
+	// actual readiness check instead of a fixed grace period.
+	WaitForCleanup(ctx context.Context, name string, migrated ConsumersMigratedFunc) (bool, error)
+
+	// GenerateCRL generates a DER-encoded certificate revocation list signed by the private key of the CA with the
+	// given name, listing the provided revoked certificates, and persists it in a secret.
+	GenerateCRL(ctx context.Context, caName string, revoked []RevokedCert) (*corev1.Secret, error)
+
+	// RotateCA force-regenerates the CA secret with the given name, and then re-signs every leaf config that was last
+	// generated with SignedByCA(caName, ...) against the new CA, replaying the exact Generate call each was last
+	// invoked with. It returns every secret it (re-)generated, starting with the CA itself. It returns an error if the
+	// CA was never generated via this manager instance.
+	RotateCA(ctx context.Context, caName string) ([]*corev1.Secret, error)
+
+	// RotatePassword force-regenerates only the password of the BasicAuthSecretConfig registered under the given
+	// name, keeping its username and format unchanged, by replaying the config it was last generated with together
+	// with any additionally given options (e.g. Rotate(KeepOld) to retain the outgoing password for a grace period,
+	// just like any other Generate call). It returns an error if no BasicAuthSecretConfig was ever generated with
+	// this name via this manager instance.
+	RotatePassword(ctx context.Context, name string, opts ...GenerateOption) (*corev1.Secret, error)
+
+	// List returns a summary for every secret tracked by the internal store. It does not perform any calls to the
+	// API server.
+	List() []Summary
+
+	// Expiring returns the names and expiry times of every secret tracked by the internal store which will expire
+	// within the given duration from now, as measured by the manager's clock. Secrets without a configured validity
+	// are excluded. It does not perform any calls to the API server.
+	Expiring(within time.Duration) []ExpiringSecret
+
+	// NeedsRotation returns the names of every secret tracked by the internal store whose current data is older than
+	// maxAge, as measured by the manager's clock, regardless of its validity. Callers can re-Generate the returned
+	// names with the Rotate option. It does not perform any calls to the API server.
+	NeedsRotation(maxAge time.Duration) []string
+
+	// Verify re-fetches every secret tracked by the internal store from the cluster and compares it against what was
+	// last written by this manager. It returns the sorted names of all secrets whose live state diverges from the
+	// expected one, without mutating anything.
+	Verify(ctx context.Context) ([]string, error)
+
+	// Flush persists the manager's lightweight bookkeeping (rotation times and lifetime labels, no secret data) to a
+	// manager-owned ConfigMap so that a future call to New can reuse it instead of re-deriving it from a full listing
+	// of the cluster's secrets.
+	Flush(ctx context.Context) error
+
+	// Refresh re-lists all secrets matching this manager's identity labels from the cluster and rebuilds the internal
+	// store (current/old/bundle classification) from them, discarding whatever was tracked before. Call this if the
+	// store might have diverged from the cluster, e.g. because another controller or a manual edit changed or deleted
+	// a secret behind the manager's back.
+	Refresh(ctx context.Context) error
+
+	// Report returns a Status for every secret tracked by the internal store, combining the data returned by List
+	// with a live Get call against the cluster for each secret's current variant. Unlike List, it does perform calls
+	// to the API server.
+	Report(ctx context.Context) ([]Status, error)
+
+	// PruneBundles lists all bundle secrets for the CA with the given name, sorts them by their 'issued-at-time'
+	// label (newest first), and deletes all but the newest 'keep' of them. The bundle secret currently referenced in
+	// the internal store, if any, is never deleted.
+	PruneBundles(ctx context.Context, caName string, keep int) error
+
+	// Migrate relabels and immutabilizes any well-known, pre-secrets-manager legacy secrets still found in the
+	// manager's namespace without the standard managed-by/identity labels, so that they no longer leak as orphaned,
+	// unmanaged objects. Unlike Generate's built-in adoption, it does not require the corresponding config to be
+	// passed through Generate first.
+	Migrate(ctx context.Context) error
 }
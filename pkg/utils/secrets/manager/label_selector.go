@@ -0,0 +1,104 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LabelSelector returns a client.MatchingLabelsSelector matching the secrets managed by this manager's identity,
+// e.g. for listing them via a client.List call. By default, it matches every secret (current, old, and bundle)
+// managed by this manager; use ForName and/or ForBundles to narrow it down.
+func (m *manager) LabelSelector(opts ...SelectorOption) client.MatchingLabelsSelector {
+	options := &SelectorOptions{}
+	options.ApplyOptions(opts)
+
+	selector := labels.SelectorFromSet(labels.Set{
+		LabelKeyManagedBy:       LabelValueSecretsManager,
+		LabelKeyManagerIdentity: m.identity,
+	})
+
+	nameLabelKey := LabelKeyName
+	if options.Bundles {
+		nameLabelKey = LabelKeyBundleFor
+	}
+
+	var (
+		requirement *labels.Requirement
+		err         error
+	)
+	if options.Name != "" {
+		requirement, err = labels.NewRequirement(nameLabelKey, selection.Equals, []string{options.Name})
+	} else if options.Bundles {
+		requirement, err = labels.NewRequirement(nameLabelKey, selection.Exists, nil)
+	}
+	if err != nil {
+		// Only returns an error for a malformed key/value/operator, none of which can happen with the constants and
+		// validated inputs used above.
+		panic(err)
+	}
+	if requirement != nil {
+		selector = selector.Add(*requirement)
+	}
+
+	return client.MatchingLabelsSelector{Selector: selector}
+}
+
+// SelectorOption is some configuration that modifies options for a LabelSelector request.
+type SelectorOption interface {
+	// ApplyToOptions applies this configuration to the given options.
+	ApplyToOptions(*SelectorOptions)
+}
+
+// SelectorOptions are options for LabelSelector calls.
+type SelectorOptions struct {
+	// Name narrows the selector to the secret(s) generated for a specific config name.
+	Name string
+	// Bundles narrows the selector to bundle secrets, either for the config name set via ForName, or for any config
+	// name if ForName is not also used.
+	Bundles bool
+}
+
+// ApplyOptions applies the given update options on these options, and then returns itself (for convenient chaining).
+func (o *SelectorOptions) ApplyOptions(opts []SelectorOption) *SelectorOptions {
+	for _, opt := range opts {
+		opt.ApplyToOptions(o)
+	}
+	return o
+}
+
+// ForName returns a function which narrows a LabelSelector to the secret(s) generated for the given config name.
+func ForName(name string) SelectorOption {
+	return nameSelectorOption{name: name}
+}
+
+type nameSelectorOption struct {
+	name string
+}
+
+func (n nameSelectorOption) ApplyToOptions(options *SelectorOptions) {
+	options.Name = n.name
+}
+
+// ForBundles is a SelectorOption which narrows a LabelSelector to bundle secrets.
+var ForBundles = bundlesSelectorOption{}
+
+type bundlesSelectorOption struct{}
+
+func (bundlesSelectorOption) ApplyToOptions(options *SelectorOptions) {
+	options.Bundles = true
+}
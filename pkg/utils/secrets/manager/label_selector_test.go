@@ -0,0 +1,123 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("LabelSelector", func() {
+	const (
+		identity  = "test"
+		namespace = "shoot--foo--bar"
+		name      = "config"
+	)
+
+	var (
+		ctx = context.TODO()
+
+		m          *manager
+		fakeClient client.Client
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetesscheme.Scheme).Build()
+
+		mgr, err := New(ctx, logr.Discard(), clock.RealClock{}, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+	})
+
+	It("should match the current secret created by Generate", func() {
+		secret, err := m.Generate(ctx, &secretutils.BasicAuthSecretConfig{
+			Name:           name,
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "foo",
+			PasswordLength: 3,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		secretList := &corev1.SecretList{}
+		Expect(fakeClient.List(ctx, secretList, client.InNamespace(namespace), m.LabelSelector())).To(Succeed())
+		Expect(secretList.Items).To(ConsistOf(*withoutTypeMeta(secret)))
+	})
+
+	It("should narrow the match to the secret(s) for a specific config name via ForName", func() {
+		secret, err := m.Generate(ctx, &secretutils.BasicAuthSecretConfig{
+			Name:           name,
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "foo",
+			PasswordLength: 3,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = m.Generate(ctx, &secretutils.BasicAuthSecretConfig{
+			Name:           "other-config",
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "bar",
+			PasswordLength: 3,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		secretList := &corev1.SecretList{}
+		Expect(fakeClient.List(ctx, secretList, client.InNamespace(namespace), m.LabelSelector(ForName(name)))).To(Succeed())
+		Expect(secretList.Items).To(ConsistOf(*withoutTypeMeta(secret)))
+	})
+
+	It("should narrow the match to bundle secrets via ForBundles", func() {
+		caSecret, err := m.Generate(ctx, &secretutils.CertificateSecretConfig{
+			Name:       "ca",
+			CommonName: "ca",
+			CertType:   secretutils.CACert,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		bundleSecret, found := m.Get("ca", Bundle)
+		Expect(found).To(BeTrue())
+		Expect(bundleSecret).NotTo(Equal(caSecret))
+
+		secretList := &corev1.SecretList{}
+		Expect(fakeClient.List(ctx, secretList, client.InNamespace(namespace), m.LabelSelector(ForBundles))).To(Succeed())
+		Expect(secretList.Items).To(ConsistOf(*withoutTypeMeta(bundleSecret)))
+	})
+
+	It("should narrow the match to the bundle secret for a specific config name via ForName and ForBundles", func() {
+		caSecret, err := m.Generate(ctx, &secretutils.CertificateSecretConfig{
+			Name:       "ca",
+			CommonName: "ca",
+			CertType:   secretutils.CACert,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_ = caSecret
+
+		bundleSecret, found := m.Get("ca", Bundle)
+		Expect(found).To(BeTrue())
+
+		secretList := &corev1.SecretList{}
+		Expect(fakeClient.List(ctx, secretList, client.InNamespace(namespace), m.LabelSelector(ForName("ca"), ForBundles))).To(Succeed())
+		Expect(secretList.Items).To(ConsistOf(*withoutTypeMeta(bundleSecret)))
+	})
+})
@@ -0,0 +1,148 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"strconv"
+	"time"
+)
+
+// Summary contains condensed information about a secret managed by this identity.
+type Summary struct {
+	// Namespace is the namespace the secret was generated in.
+	Namespace string
+	// Name is the name of the secret configuration (not the actual, checksum-suffixed secret name).
+	Name string
+	// IssuedAt is the time when the current secret's data was created.
+	IssuedAt *time.Time
+	// ValidUntil is the time until when the current secret's data is valid.
+	ValidUntil *time.Time
+	// HasOld specifies whether an old variant of the secret exists.
+	HasOld bool
+	// HasBundle specifies whether a bundle secret exists for the secret.
+	HasBundle bool
+	// Persist specifies whether the secret is labeled to be persisted.
+	Persist bool
+}
+
+// ExpiringSecret contains condensed information about a managed secret which will expire soon.
+type ExpiringSecret struct {
+	// Name is the name of the secret configuration (not the actual, checksum-suffixed secret name).
+	Name string
+	// ValidUntil is the time until when the current secret's data is valid.
+	ValidUntil time.Time
+}
+
+// Expiring returns the names and expiry times of every secret tracked by the internal store whose current data's
+// 'valid-until-time' label falls within the given duration from now, as measured by the manager's clock. Secrets
+// without a 'valid-until-time' label (e.g. those without a configured validity) are excluded. It does not perform
+// any calls to the API server.
+func (m *manager) Expiring(within time.Duration) []ExpiringSecret {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	threshold := m.clock.Now().Add(within)
+
+	var expiring []ExpiringSecret
+
+	for key, secrets := range m.store {
+		v := secrets.current.obj.Labels[LabelKeyValidUntilTime]
+		if v == "" {
+			continue
+		}
+
+		unix, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		validUntil := time.Unix(unix, 0).UTC()
+		if validUntil.Before(threshold) {
+			expiring = append(expiring, ExpiringSecret{Name: key.name, ValidUntil: validUntil})
+		}
+	}
+
+	return expiring
+}
+
+// NeedsRotation returns the names of every secret tracked by the internal store whose current data's
+// 'issued-at-time' label is older than maxAge, as measured by the manager's clock, regardless of the secret's
+// validity. This is intended for policies that mandate rotating certain secrets (e.g. passwords) after a maximum
+// age, independent of expiry; callers can re-Generate the returned names with the Rotate option. Secrets without an
+// 'issued-at-time' label are excluded. It does not perform any calls to the API server.
+func (m *manager) NeedsRotation(maxAge time.Duration) []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	threshold := m.clock.Now().Add(-maxAge)
+
+	var names []string
+
+	for key, secrets := range m.store {
+		v := secrets.current.obj.Labels[LabelKeyIssuedAtTime]
+		if v == "" {
+			continue
+		}
+
+		unix, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		issuedAt := time.Unix(unix, 0).UTC()
+		if issuedAt.Before(threshold) {
+			names = append(names, key.name)
+		}
+	}
+
+	return names
+}
+
+// List returns a summary for every secret tracked by the internal store. It does not perform any calls to the API
+// server.
+func (m *manager) List() []Summary {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	summaries := make([]Summary, 0, len(m.store))
+
+	for key, secrets := range m.store {
+		summary := Summary{
+			Namespace: key.namespace,
+			Name:      key.name,
+			HasOld:    secrets.old != nil,
+			HasBundle: secrets.bundle != nil,
+			Persist:   secrets.current.obj.Labels[LabelKeyPersist] == LabelValueTrue,
+		}
+
+		if v := secrets.current.obj.Labels[LabelKeyIssuedAtTime]; v != "" {
+			if unix, err := strconv.ParseInt(v, 10, 64); err == nil {
+				issuedAt := time.Unix(unix, 0).UTC()
+				summary.IssuedAt = &issuedAt
+			}
+		}
+
+		if v := secrets.current.obj.Labels[LabelKeyValidUntilTime]; v != "" {
+			if unix, err := strconv.ParseInt(v, 10, 64); err == nil {
+				validUntil := time.Unix(unix, 0).UTC()
+				summary.ValidUntil = &validUntil
+			}
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/gardener/gardener/pkg/utils"
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("List", func() {
+	var (
+		ctx        = context.TODO()
+		namespace  = "shoot--foo--bar"
+		identity   = "test"
+		fakeClient client.Client
+		fakeClock  = clock.NewFakeClock(time.Time{})
+
+		m *manager
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetesscheme.Scheme).Build()
+
+		mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+	})
+
+	It("should return summaries for basic auth, a CA with a bundle, and a rotated certificate", func() {
+		basicAuthConfig := &secretutils.BasicAuthSecretConfig{
+			Name:           "basic-auth",
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "admin",
+			PasswordLength: 32,
+		}
+		_, err := m.Generate(ctx, basicAuthConfig, Persist())
+		Expect(err).NotTo(HaveOccurred())
+
+		caConfig := &secretutils.CertificateSecretConfig{
+			Name:       "ca",
+			CommonName: "ca",
+			CertType:   secretutils.CACert,
+		}
+		_, err = m.Generate(ctx, caConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		caConfig.Organization = []string{"new-org"}
+		_, err = m.Generate(ctx, caConfig, Rotate(KeepOld))
+		Expect(err).NotTo(HaveOccurred())
+
+		summaries := m.List()
+		Expect(summaries).To(HaveLen(2))
+
+		byName := map[string]Summary{}
+		for _, s := range summaries {
+			byName[s.Name] = s
+		}
+
+		Expect(byName["basic-auth"].Persist).To(BeTrue())
+		Expect(byName["basic-auth"].HasOld).To(BeFalse())
+		Expect(byName["basic-auth"].HasBundle).To(BeFalse())
+
+		Expect(byName["ca"].HasBundle).To(BeTrue())
+		Expect(byName["ca"].HasOld).To(BeTrue())
+		Expect(byName["ca"].IssuedAt).NotTo(BeNil())
+		Expect(byName["ca"].ValidUntil).NotTo(BeNil())
+	})
+
+	Describe("#Expiring", func() {
+		It("should only return secrets with a valid-until-time label within the given threshold", func() {
+			basicAuthConfig := &secretutils.BasicAuthSecretConfig{
+				Name:           "basic-auth",
+				Format:         secretutils.BasicAuthFormatNormal,
+				Username:       "admin",
+				PasswordLength: 32,
+			}
+			_, err := m.Generate(ctx, basicAuthConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			soonToExpireConfig := &secretutils.CertificateSecretConfig{
+				Name:       "soon-to-expire",
+				CommonName: "soon-to-expire",
+				CertType:   secretutils.CACert,
+				Clock:      fakeClock,
+				Validity:   utils.DurationPtr(2 * 24 * time.Hour),
+			}
+			_, err = m.Generate(ctx, soonToExpireConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			longLivedConfig := &secretutils.CertificateSecretConfig{
+				Name:       "long-lived",
+				CommonName: "long-lived",
+				CertType:   secretutils.CACert,
+				Clock:      fakeClock,
+			}
+			_, err = m.Generate(ctx, longLivedConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			expiring := m.Expiring(3 * 24 * time.Hour)
+			Expect(expiring).To(HaveLen(1))
+			Expect(expiring[0].Name).To(Equal("soon-to-expire"))
+			Expect(expiring[0].ValidUntil).To(Equal(fakeClock.Now().Add(2 * 24 * time.Hour).UTC()))
+		})
+	})
+
+	Describe("#NeedsRotation", func() {
+		It("should only return secrets whose issued-at-time is older than the given maxAge", func() {
+			oldConfig := &secretutils.BasicAuthSecretConfig{
+				Name:           "old",
+				Format:         secretutils.BasicAuthFormatNormal,
+				Username:       "admin",
+				PasswordLength: 32,
+			}
+			_, err := m.Generate(ctx, oldConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeClock.Step(10 * 24 * time.Hour)
+
+			freshConfig := &secretutils.BasicAuthSecretConfig{
+				Name:           "fresh",
+				Format:         secretutils.BasicAuthFormatNormal,
+				Username:       "admin",
+				PasswordLength: 32,
+			}
+			_, err = m.Generate(ctx, freshConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(m.NeedsRotation(5 * 24 * time.Hour)).To(ConsistOf("old"))
+		})
+	})
+})
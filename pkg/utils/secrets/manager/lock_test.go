@@ -0,0 +1,88 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("concurrent Generate calls", func() {
+	var (
+		ctx        = context.TODO()
+		namespace  = "shoot--foo--bar"
+		identity   = "test"
+		fakeClient client.Client
+		fakeClock  = clock.NewFakeClock(time.Time{})
+
+		m *manager
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetesscheme.Scheme).Build()
+
+		mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+	})
+
+	It("should not race when generating overlapping and distinct names concurrently", func() {
+		const (
+			names       = 5
+			generations = 10
+		)
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < names; i++ {
+			for j := 0; j < generations; j++ {
+				wg.Add(1)
+				go func(name string) {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					config := &secretutils.BasicAuthSecretConfig{
+						Name:           name,
+						Format:         secretutils.BasicAuthFormatNormal,
+						Username:       "foo",
+						PasswordLength: 3,
+					}
+
+					_, err := m.Generate(ctx, config)
+					Expect(err).NotTo(HaveOccurred())
+				}(fmt.Sprintf("config-%d", i))
+			}
+		}
+
+		wg.Wait()
+
+		for i := 0; i < names; i++ {
+			_, found := m.getFromStore(namespace, fmt.Sprintf("config-%d", i))
+			Expect(found).To(BeTrue())
+		}
+	})
+})
@@ -16,6 +16,7 @@ package manager
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"sync"
 	"time"
@@ -49,6 +50,13 @@ const (
 	// LabelKeyBundleFor is a constant for a key of a label on a Secret describing that it is a bundle secret for
 	// another secret.
 	LabelKeyBundleFor = "bundle-for"
+	// LabelKeyTrustBundleFor is a constant for a key of a label on a ConfigMap describing that it mirrors the CA
+	// bundle secret for another secret as a trust bundle ConfigMap.
+	LabelKeyTrustBundleFor = "trust-bundle-for"
+	// LabelKeyAliasFor is a constant for a key of a label on a Secret describing that it is the stable-named, mutable
+	// alias of the checksum-named secret for the config with the given name, maintained by the WithStableNameAlias
+	// option.
+	LabelKeyAliasFor = "alias-for"
 	// LabelKeyPersist is a constant for a key of a label on a Secret describing that it should get persisted.
 	LabelKeyPersist = "persist"
 	// LabelKeyLastRotationInitiationTime is a constant for a key of a label on a Secret describing the unix timestamps
@@ -61,11 +69,46 @@ const (
 	// data is valid. In case the data contains a certificate it is the time part of the certificate's 'not after'
 	// field.
 	LabelKeyValidUntilTime = "valid-until-time"
+	// LabelKeyRotationPhase is a constant for a key of a label on a Secret describing which phase of a KeepOld
+	// rotation the current secret is in, see LabelValueRotationPhasePreparing, LabelValueRotationPhaseCompleting, and
+	// LabelValueRotationPhaseCompleted. It is absent for secrets that were never rotated with RotationStrategy
+	// KeepOld.
+	LabelKeyRotationPhase = "rotation-phase"
+
+	// AnnotationKeyCertificateFingerprintSHA256 is a constant for a key of an annotation on a Secret containing a
+	// certificate, holding the hex-encoded SHA-256 fingerprint of the certificate's raw DER bytes.
+	AnnotationKeyCertificateFingerprintSHA256 = "cert-fingerprint-sha256"
+	// AnnotationKeySignedByCA is a constant for a key of an annotation on a Secret containing a certificate signed via
+	// the SignedByCA option, holding the name of the CA config that signed it.
+	AnnotationKeySignedByCA = "signed-by-ca"
+	// AnnotationKeySignedByCAChecksum is a constant for a key of an annotation on a Secret containing a certificate
+	// signed via the SignedByCA option, holding the checksum of the CA certificate data used to sign it at the time
+	// of signing. It changes whenever the leaf is re-signed with a different generation of the CA.
+	AnnotationKeySignedByCAChecksum = "signed-by-ca-checksum"
+	// AnnotationKeyNextRenewalTime is a constant for a key of an annotation on a Secret containing a certificate
+	// generated with the WithRenewalWindow option, holding the unix timestamp at which an external controller or
+	// reloader should expect the secret to be regenerated, i.e. 'valid-until-time' minus the configured window.
+	AnnotationKeyNextRenewalTime = "next-renewal-time"
+	// AnnotationKeyDataChecksum is a constant for a key of an annotation on a Secret holding the checksum of its data
+	// map. It only changes when the data map itself changes, allowing consumers to detect a rotation (e.g. to trigger
+	// a pod roll-out) without comparing the full payload.
+	AnnotationKeyDataChecksum = "data-checksum"
 
 	// LabelValueTrue is a constant for a value of a label on a Secret describing the value 'true'.
 	LabelValueTrue = "true"
 	// LabelValueSecretsManager is a constant for a value of a label on a Secret describing the value 'secret-manager'.
 	LabelValueSecretsManager = "secrets-manager"
+	// LabelValueRotationPhasePreparing is a value for LabelKeyRotationPhase set on the current secret as soon as a
+	// KeepOld rotation has stored the previous generation as the 'old' secret, so that consumers still holding a
+	// reference to it keep working while they migrate to the current one.
+	LabelValueRotationPhasePreparing = "preparing"
+	// LabelValueRotationPhaseCompleting is a value for LabelKeyRotationPhase set on the current secret once
+	// CompleteRotation has been called for it but its grace period has not yet elapsed, i.e. the 'old' secret is
+	// still being kept around until every consumer had a chance to migrate.
+	LabelValueRotationPhaseCompleting = "completing"
+	// LabelValueRotationPhaseCompleted is a value for LabelKeyRotationPhase set on the current secret once
+	// CompleteRotation has deleted its 'old' secret because the grace period has elapsed.
+	LabelValueRotationPhaseCompleted = "completed"
 
 	nameSuffixBundle = "-bundle"
 )
@@ -79,16 +122,47 @@ type (
 		client                      client.Client
 		namespace                   string
 		identity                    string
+		namePrefix                  string
 		lastRotationInitiationTimes nameToUnixTime
+		lastRotationReasons         map[string]RotationReason
+		nameChecksumFunc            NameChecksumFunc
+		serialNumberFunc            SerialNumberFunc
+
+		generateLocksLock sync.Mutex
+		generateLocks     map[string]*sync.Mutex
+
+		// dependentsLock guards configs and caDependents.
+		dependentsLock sync.Mutex
+		// configs tracks, for every non-bundle secret config name, the ConfigInterface and GenerateOptions it was last
+		// generated with, so that RotateCA can replay the same Generate call for a CA and its dependents.
+		configs map[string]dependentRegistration
+		// caDependents tracks, for every CA name, the set of leaf config names last generated with
+		// SignedByCA(caName, ...), so that RotateCA knows which dependents to re-sign.
+		caDependents map[string]map[string]struct{}
+	}
+
+	// dependentRegistration tracks the ConfigInterface and GenerateOptions a config was last generated with, so that
+	// RotateCA can replay the same Generate call to regenerate or re-sign it.
+	dependentRegistration struct {
+		config secretutils.ConfigInterface
+		opts   []GenerateOption
 	}
 
 	nameToUnixTime map[string]string
 
-	secretStore map[string]secretInfos
+	secretStoreKey struct {
+		namespace string
+		name      string
+	}
+	secretStore map[secretStoreKey]secretInfos
 	secretInfos struct {
 		current secretInfo
 		old     *secretInfo
 		bundle  *secretInfo
+		// retained holds the secrets kept by a KeepLastN rotation strategy, newest first and bounded to n. It is nil
+		// unless KeepLastN was used; old is populated alongside it with retained[0] for compatibility with
+		// CompleteRotation, WaitForCleanup and Cleanup, which only ever deal with a single 'old' secret.
+		retained []secretInfo
 	}
 	secretInfo struct {
 		obj                        *corev1.Secret
@@ -107,7 +181,14 @@ const (
 	bundle  secretClass = "bundle"
 )
 
-// New returns a new manager for secrets in a given namespace.
+// New returns a new manager for secrets in a given namespace. The optional namePrefix (only its first value is
+// considered) is prepended to the name of every secret this manager creates. This allows two managers with
+// different identities to share the same namespace without colliding on secret names, e.g. for multi-tenant
+// isolation. The manager captures the package-level DefaultNameChecksumFunc and DefaultSerialNumberFunc at
+// construction time; to stage a migration to a different checksum algorithm or serial number source (e.g. one
+// tracked in an external registry for CRL/OCSP coordination), swap the respective default before constructing the
+// managers that should use it. Already-constructed managers keep using whichever functions were in effect when they
+// were created.
 func New(
 	ctx context.Context,
 	logger logr.Logger,
@@ -116,10 +197,15 @@ func New(
 	namespace string,
 	identity string,
 	secretNamesToTimes map[string]time.Time,
+	namePrefix ...string,
 ) (
 	Interface,
 	error,
 ) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context cancelled before secrets manager could be initialized: %w", err)
+	}
+
 	m := &manager{
 		store:                       make(secretStore),
 		clock:                       clock,
@@ -127,7 +213,14 @@ func New(
 		client:                      c,
 		namespace:                   namespace,
 		identity:                    identity,
+		namePrefix:                  firstOrEmpty(namePrefix),
 		lastRotationInitiationTimes: make(map[string]string),
+		lastRotationReasons:         make(map[string]RotationReason),
+		nameChecksumFunc:            DefaultNameChecksumFunc,
+		serialNumberFunc:            DefaultSerialNumberFunc,
+		generateLocks:               make(map[string]*sync.Mutex),
+		configs:                     make(map[string]dependentRegistration),
+		caDependents:                make(map[string]map[string]struct{}),
 	}
 
 	if err := m.initialize(ctx, secretNamesToTimes); err != nil {
@@ -137,6 +230,23 @@ func New(
 	return m, nil
 }
 
+// Identity returns the identity this manager was created with.
+func (m *manager) Identity() string {
+	return m.identity
+}
+
+// Namespace returns the namespace this manager was created for.
+func (m *manager) Namespace() string {
+	return m.namespace
+}
+
+func firstOrEmpty(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
 func (m *manager) listSecrets(ctx context.Context) (*corev1.SecretList, error) {
 	secretList := &corev1.SecretList{}
 	return secretList, m.client.List(ctx, secretList, client.InNamespace(m.namespace), client.MatchingLabels{
@@ -146,33 +256,58 @@ func (m *manager) listSecrets(ctx context.Context) (*corev1.SecretList, error) {
 }
 
 func (m *manager) initialize(ctx context.Context, secretNamesToTimes map[string]time.Time) error {
-	secretList, err := m.listSecrets(ctx)
+	snapshot, err := m.loadSnapshot(ctx)
 	if err != nil {
 		return err
 	}
 
-	nameToNewestSecret := make(map[string]corev1.Secret, len(secretList.Items))
+	if snapshot != nil {
+		m.logger.Info("Reusing secrets manager snapshot instead of listing all secrets", "configMap", snapshotConfigMapName(m.identity))
 
-	// Find the newest secret in system for the respective secret names. Read their existing
-	// last-rotation-initiation-time labels and store them in our internal map.
-	for _, secret := range secretList.Items {
-		oldSecret, found := nameToNewestSecret[secret.Labels[LabelKeyName]]
-		if !found || oldSecret.CreationTimestamp.Time.Before(secret.CreationTimestamp.Time) {
-			nameToNewestSecret[secret.Labels[LabelKeyName]] = *secret.DeepCopy()
-			m.lastRotationInitiationTimes[secret.Labels[LabelKeyName]] = secret.Labels[LabelKeyLastRotationInitiationTime]
-		}
-	}
+		for name, entry := range snapshot.Secrets {
+			m.lastRotationInitiationTimes[name] = entry.LastRotationInitiationTime
 
-	// Check if the secrets must be automatically renewed because they are about to expire.
-	for name, secret := range nameToNewestSecret {
-		mustRenew, err := m.mustAutoRenewSecret(secret)
+			mustRenew, err := m.mustAutoRenewSecret(entry.IssuedAtTime, entry.ValidUntilTime)
+			if err != nil {
+				return err
+			}
+
+			if mustRenew {
+				m.logger.Info("Preparing secret for automatic renewal", "name", name, "issuedAt", entry.IssuedAtTime, "validUntil", entry.ValidUntilTime)
+				m.lastRotationInitiationTimes[name] = unixTime(m.clock.Now())
+			}
+		}
+	} else {
+		secretList, err := m.listSecrets(ctx)
 		if err != nil {
 			return err
 		}
 
-		if mustRenew {
-			m.logger.Info("Preparing secret for automatic renewal", "secret", secret.Name, "issuedAt", secret.Labels[LabelKeyIssuedAtTime], "validUntil", secret.Labels[LabelKeyValidUntilTime])
-			m.lastRotationInitiationTimes[name] = unixTime(m.clock.Now())
+		nameToNewestSecret := make(map[string]corev1.Secret, len(secretList.Items))
+
+		// Find the newest secret in system for the respective secret names. Read their existing
+		// last-rotation-initiation-time labels and store them in our internal map. This allows the manager to
+		// bootstrap its rotation bookkeeping from the cluster after a restart instead of requiring the caller to
+		// reconstruct it. Secrets without the label (or with an empty value) are treated as "never rotated".
+		for _, secret := range secretList.Items {
+			oldSecret, found := nameToNewestSecret[secret.Labels[LabelKeyName]]
+			if !found || oldSecret.CreationTimestamp.Time.Before(secret.CreationTimestamp.Time) {
+				nameToNewestSecret[secret.Labels[LabelKeyName]] = *secret.DeepCopy()
+				m.lastRotationInitiationTimes[secret.Labels[LabelKeyName]] = secret.Labels[LabelKeyLastRotationInitiationTime]
+			}
+		}
+
+		// Check if the secrets must be automatically renewed because they are about to expire.
+		for name, secret := range nameToNewestSecret {
+			mustRenew, err := m.mustAutoRenewSecret(secret.Labels[LabelKeyIssuedAtTime], secret.Labels[LabelKeyValidUntilTime])
+			if err != nil {
+				return err
+			}
+
+			if mustRenew {
+				m.logger.Info("Preparing secret for automatic renewal", "secret", secret.Name, "issuedAt", secret.Labels[LabelKeyIssuedAtTime], "validUntil", secret.Labels[LabelKeyValidUntilTime])
+				m.lastRotationInitiationTimes[name] = unixTime(m.clock.Now())
+			}
 		}
 	}
 
@@ -184,17 +319,17 @@ func (m *manager) initialize(ctx context.Context, secretNamesToTimes map[string]
 	return nil
 }
 
-func (m *manager) mustAutoRenewSecret(secret corev1.Secret) (bool, error) {
-	if secret.Labels[LabelKeyIssuedAtTime] == "" || secret.Labels[LabelKeyValidUntilTime] == "" {
+func (m *manager) mustAutoRenewSecret(issuedAtTime, validUntilTime string) (bool, error) {
+	if issuedAtTime == "" || validUntilTime == "" {
 		return false, nil
 	}
 
-	issuedAtUnix, err := strconv.ParseInt(secret.Labels[LabelKeyIssuedAtTime], 10, 64)
+	issuedAtUnix, err := strconv.ParseInt(issuedAtTime, 10, 64)
 	if err != nil {
 		return false, err
 	}
 
-	validUntilUnix, err := strconv.ParseInt(secret.Labels[LabelKeyValidUntilTime], 10, 64)
+	validUntilUnix, err := strconv.ParseInt(validUntilTime, 10, 64)
 	if err != nil {
 		return false, err
 	}
@@ -211,7 +346,22 @@ func (m *manager) mustAutoRenewSecret(secret corev1.Secret) (bool, error) {
 	return now.After(renewAt) || now.After(validUntil.Add(-10*24*time.Hour)), nil
 }
 
-func (m *manager) addToStore(name string, secret *corev1.Secret, class secretClass) error {
+// lockForName returns a mutex dedicated to the given secret config name. Concurrent Generate calls for the same name
+// serialize on this mutex, while calls for different names can proceed in parallel.
+func (m *manager) lockForName(name string) *sync.Mutex {
+	m.generateLocksLock.Lock()
+	defer m.generateLocksLock.Unlock()
+
+	nameLock, ok := m.generateLocks[name]
+	if !ok {
+		nameLock = &sync.Mutex{}
+		m.generateLocks[name] = nameLock
+	}
+
+	return nameLock
+}
+
+func (m *manager) addToStore(namespace, name string, secret *corev1.Secret, class secretClass) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
@@ -220,7 +370,8 @@ func (m *manager) addToStore(name string, secret *corev1.Secret, class secretCla
 		return err
 	}
 
-	secrets := m.store[name]
+	key := secretStoreKey{namespace: namespace, name: name}
+	secrets := m.store[key]
 
 	switch class {
 	case current:
@@ -231,19 +382,71 @@ func (m *manager) addToStore(name string, secret *corev1.Secret, class secretCla
 		secrets.bundle = &info
 	}
 
-	m.store[name] = secrets
+	m.store[key] = secrets
+
+	return nil
+}
+
+// addRetainedToStore stores the given secrets as the secrets retained by a KeepLastN rotation strategy for the given
+// name, replacing whatever was tracked before. secrets must be ordered newest first.
+func (m *manager) addRetainedToStore(namespace, name string, secrets []*corev1.Secret) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	infos := make([]secretInfo, 0, len(secrets))
+	for _, secret := range secrets {
+		info, err := computeSecretInfo(secret)
+		if err != nil {
+			return err
+		}
+		infos = append(infos, info)
+	}
 
+	key := secretStoreKey{namespace: namespace, name: name}
+	entry := m.store[key]
+	entry.retained = infos
+	m.store[key] = entry
 	return nil
 }
 
-func (m *manager) getFromStore(name string) (secretInfos, bool) {
+// removeOldFromStore clears the 'old' secret tracked for the given name from the internal store, leaving 'current'
+// and 'bundle' untouched. It is a no-op if no 'old' secret is tracked.
+func (m *manager) removeOldFromStore(namespace, name string) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
-	secrets, ok := m.store[name]
+	key := secretStoreKey{namespace: namespace, name: name}
+	secrets := m.store[key]
+	secrets.old = nil
+	m.store[key] = secrets
+}
+
+func (m *manager) getFromStore(namespace, name string) (secretInfos, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	secrets, ok := m.store[secretStoreKey{namespace: namespace, name: name}]
 	return secrets, ok
 }
 
+// setLastRotationReason records the reason the most recent Generate call for the secret with the given config name
+// decided to create a new secret.
+func (m *manager) setLastRotationReason(name string, reason RotationReason) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.lastRotationReasons[name] = reason
+}
+
+// getLastRotationReason returns the reason recorded by setLastRotationReason for the secret with the given config
+// name, or the empty string if Generate has not (re-)created it since the manager was created.
+func (m *manager) getLastRotationReason(name string) RotationReason {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.lastRotationReasons[name]
+}
+
 func computeSecretInfo(obj *corev1.Secret) (secretInfo, error) {
 	var (
 		lastRotationStartTime int64
@@ -264,7 +467,29 @@ func computeSecretInfo(obj *corev1.Secret) (secretInfo, error) {
 	}, nil
 }
 
-// ObjectMeta returns the object meta based on the given settings.
+// NameChecksumFunc computes the checksum string embedded in generated secret names (see computeSecretName). Given
+// the same input, it must always return the same output. It is not required to be cryptographically secure, only
+// to make accidental collisions between unrelated inputs unlikely.
+type NameChecksumFunc func(data []byte) string
+
+// DefaultNameChecksumFunc is the default NameChecksumFunc used by ObjectMeta if none is given. It produces a
+// hex-encoded SHA-256 digest of the input.
+var DefaultNameChecksumFunc NameChecksumFunc = utils.ComputeSHA256Hex
+
+// SerialNumberFunc produces the serial number for a certificate Generate is about to mint. Implementations must
+// return a positive, unique value; the default draws one at random, but a deployment that must guarantee monotonic
+// or registry-tracked serials (e.g. for CRL/OCSP coordination) can swap DefaultSerialNumberFunc for one backed by
+// such a registry.
+type SerialNumberFunc = secretutils.SerialNumberFunc
+
+// DefaultSerialNumberFunc is the default SerialNumberFunc captured by every manager created via New, unless
+// DefaultSerialNumberFunc is swapped beforehand. It delegates to secretutils.DefaultSerialNumberFunc.
+var DefaultSerialNumberFunc SerialNumberFunc = secretutils.DefaultSerialNumberFunc
+
+// ObjectMeta returns the object meta based on the given settings. namePrefix, if non-empty, is prepended to the
+// computed secret name. nameChecksumFunc computes the checksum embedded in the name; if nil, DefaultNameChecksumFunc
+// is used. Passing an alternate nameChecksumFunc changes the names of all secrets generated with it, so it should
+// only be done in a staged fashion (e.g. for a new manager identity) to avoid unexpected secret churn.
 func ObjectMeta(
 	namespace string,
 	managerIdentity string,
@@ -275,10 +500,16 @@ func ObjectMeta(
 	signingCAChecksum *string,
 	persist *bool,
 	bundleFor *string,
+	namePrefix string,
+	nameChecksumFunc NameChecksumFunc,
 ) (
 	metav1.ObjectMeta,
 	error,
 ) {
+	if nameChecksumFunc == nil {
+		nameChecksumFunc = DefaultNameChecksumFunc
+	}
+
 	configHash, err := hashstructure.Hash(config, hashstructure.FormatV2, &hashstructure.HashOptions{IgnoreZeroValue: true})
 	if err != nil {
 		return metav1.ObjectMeta{}, err
@@ -309,13 +540,13 @@ func ObjectMeta(
 	}
 
 	return metav1.ObjectMeta{
-		Name:      computeSecretName(config, labels, ignoreConfigChecksumForCASecretName),
+		Name:      namePrefix + computeSecretName(config, labels, ignoreConfigChecksumForCASecretName, nameChecksumFunc),
 		Namespace: namespace,
 		Labels:    labels,
 	}, nil
 }
 
-func computeSecretName(config secretutils.ConfigInterface, labels map[string]string, ignoreConfigChecksumForCASecretName bool) string {
+func computeSecretName(config secretutils.ConfigInterface, labels map[string]string, ignoreConfigChecksumForCASecretName bool, nameChecksumFunc NameChecksumFunc) string {
 	name := config.GetName()
 
 	// For backwards-compatibility, we might need to keep the static names of the CA secrets so that external components
@@ -323,17 +554,26 @@ func computeSecretName(config secretutils.ConfigInterface, labels map[string]str
 	// config checksum is considered for the name computation.
 	if cfg, ok := config.(*secretutils.CertificateSecretConfig); !ok || cfg.SigningCA != nil || !ignoreConfigChecksumForCASecretName {
 		if infix := labels[LabelKeyChecksumConfig] + labels[LabelKeyChecksumSigningCA]; len(infix) > 0 {
-			name += "-" + utils.ComputeSHA256Hex([]byte(infix))[:8]
+			name += "-" + truncateChecksum(nameChecksumFunc([]byte(infix)), 8)
 		}
 	}
 
 	if suffix := labels[LabelKeyLastRotationInitiationTime]; len(suffix) > 0 {
-		name += "-" + utils.ComputeSHA256Hex([]byte(suffix))[:5]
+		name += "-" + truncateChecksum(nameChecksumFunc([]byte(suffix)), 5)
 	}
 
 	return name
 }
 
+// truncateChecksum truncates checksum to at most n characters, tolerating NameChecksumFunc implementations that
+// return a value shorter than n.
+func truncateChecksum(checksum string, n int) string {
+	if len(checksum) > n {
+		return checksum[:n]
+	}
+	return checksum
+}
+
 // Secret constructs a *corev1.Secret for the given metadata and data.
 func Secret(objectMeta metav1.ObjectMeta, data map[string][]byte) *corev1.Secret {
 	return &corev1.Secret{
@@ -345,11 +585,14 @@ func Secret(objectMeta metav1.ObjectMeta, data map[string][]byte) *corev1.Secret
 }
 
 func secretTypeForData(data map[string][]byte) corev1.SecretType {
-	secretType := corev1.SecretTypeOpaque
-	if data[secretutils.DataKeyCertificate] != nil && data[secretutils.DataKeyPrivateKey] != nil {
-		secretType = corev1.SecretTypeTLS
+	switch {
+	case data[secretutils.DataKeyCertificate] != nil && data[secretutils.DataKeyPrivateKey] != nil:
+		return corev1.SecretTypeTLS
+	case data[corev1.SSHAuthPrivateKey] != nil:
+		return corev1.SecretTypeSSHAuth
+	default:
+		return corev1.SecretTypeOpaque
 	}
-	return secretType
 }
 
 func unixTime(in time.Time) string {
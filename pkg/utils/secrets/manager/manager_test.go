@@ -16,11 +16,14 @@ package manager
 
 import (
 	"context"
+	"math/big"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gardener/gardener/pkg/utils"
 	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+	"github.com/gardener/gardener/pkg/utils/test"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
@@ -58,6 +61,38 @@ var _ = Describe("Manager", func() {
 			Expect(m.lastRotationInitiationTimes).To(BeEmpty())
 		})
 
+		It("should expose the identity and namespace it was created with", func() {
+			mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mgr.Identity()).To(Equal(identity))
+			Expect(mgr.Namespace()).To(Equal(namespace))
+		})
+
+		It("should capture the package-level DefaultNameChecksumFunc at construction time", func() {
+			alternate := func(data []byte) string { return strings.Repeat("a", len(data)) }
+			DeferCleanup(test.WithVar(&DefaultNameChecksumFunc, NameChecksumFunc(alternate)))
+
+			mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+			Expect(err).NotTo(HaveOccurred())
+			m = mgr.(*manager)
+
+			Expect(m.nameChecksumFunc([]byte("foo"))).To(Equal(alternate([]byte("foo"))))
+		})
+
+		It("should capture the package-level DefaultSerialNumberFunc at construction time", func() {
+			alternate := func() (*big.Int, error) { return big.NewInt(7), nil }
+			DeferCleanup(test.WithVar(&DefaultSerialNumberFunc, SerialNumberFunc(alternate)))
+
+			mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+			Expect(err).NotTo(HaveOccurred())
+			m = mgr.(*manager)
+
+			serialNumber, err := m.serialNumberFunc()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serialNumber).To(Equal(big.NewInt(7)))
+		})
+
 		It("should create a new instance w/ provided last rotation initiation times", func() {
 			mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{"foo": fakeClock.Now()})
 			Expect(err).NotTo(HaveOccurred())
@@ -88,6 +123,28 @@ var _ = Describe("Manager", func() {
 			Expect(m.lastRotationInitiationTimes).To(Equal(nameToUnixTime{"secret1": "-62135596800"}))
 		})
 
+		It("should treat an empty last rotation initiation time label as 'never rotated'", func() {
+			existingSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "secret1",
+					Namespace: namespace,
+					Labels: map[string]string{
+						"name":                          "secret1",
+						"managed-by":                    "secrets-manager",
+						"manager-identity":              identity,
+						"last-rotation-initiation-time": "",
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, existingSecret)).To(Succeed())
+
+			mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+			Expect(err).NotTo(HaveOccurred())
+			m = mgr.(*manager)
+
+			Expect(m.lastRotationInitiationTimes).To(Equal(nameToUnixTime{"secret1": ""}))
+		})
+
 		It("should create a new instance w/ both existing and provided last rotation initiation times", func() {
 			existingSecret := &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
@@ -244,6 +301,15 @@ var _ = Describe("Manager", func() {
 
 			Expect(m.lastRotationInitiationTimes).To(Equal(nameToUnixTime{"secret1": "24"}))
 		})
+
+		It("should return promptly with a wrapped context error if the context is already cancelled", func() {
+			cancelledCtx, cancel := context.WithCancel(ctx)
+			cancel()
+
+			mgr, err := New(cancelledCtx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+			Expect(err).To(MatchError(context.Canceled))
+			Expect(mgr).To(BeNil())
+		})
 	})
 
 	Describe("#ObjectMeta", func() {
@@ -257,7 +323,7 @@ var _ = Describe("Manager", func() {
 			func(ignoreChecksum bool, expectedName string, lastRotationInitiationTime string) {
 				config := &secretutils.CertificateSecretConfig{Name: configName}
 
-				meta, err := ObjectMeta(namespace, "test", config, ignoreChecksum, lastRotationInitiationTime, nil, nil, nil, nil)
+				meta, err := ObjectMeta(namespace, "test", config, ignoreChecksum, lastRotationInitiationTime, nil, nil, nil, nil, "", nil)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(meta).To(Equal(metav1.ObjectMeta{
@@ -279,6 +345,37 @@ var _ = Describe("Manager", func() {
 			Entry("config checksum considered, rotation", false, configName+"-fd0a3f24-76711", lastRotationInitiationTime),
 		)
 
+		It("should prepend the given name prefix to the computed name", func() {
+			config := &secretutils.CertificateSecretConfig{Name: configName}
+
+			meta, err := ObjectMeta(namespace, "test", config, true, "", nil, nil, nil, nil, "tenant1-", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(meta.Name).To(Equal("tenant1-" + configName))
+		})
+
+		It("should use DefaultNameChecksumFunc if no nameChecksumFunc is given", func() {
+			config := &secretutils.CertificateSecretConfig{Name: configName}
+
+			meta, err := ObjectMeta(namespace, "test", config, false, "", nil, nil, nil, nil, "", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(meta.Name).To(Equal(configName + "-fd0a3f24"))
+		})
+
+		It("should use the given nameChecksumFunc instead of the default, producing a different but stable name", func() {
+			config := &secretutils.CertificateSecretConfig{Name: configName}
+			alternate := func(data []byte) string { return strings.Repeat("a", len(data)) }
+
+			meta1, err := ObjectMeta(namespace, "test", config, false, "", nil, nil, nil, nil, "", alternate)
+			Expect(err).NotTo(HaveOccurred())
+			meta2, err := ObjectMeta(namespace, "test", config, false, "", nil, nil, nil, nil, "", alternate)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(meta1.Name).To(Equal(meta2.Name))
+			Expect(meta1.Name).NotTo(Equal(configName + "-fd0a3f24"))
+		})
+
 		DescribeTable("check different label options",
 			func(nameInfix string, signingCAChecksum *string, validUntilTime *string, persist *bool, bundleFor *string, extraLabels map[string]string) {
 				config := &secretutils.CertificateSecretConfig{
@@ -286,7 +383,7 @@ var _ = Describe("Manager", func() {
 					SigningCA: &secretutils.Certificate{},
 				}
 
-				meta, err := ObjectMeta(namespace, "test", config, false, lastRotationInitiationTime, validUntilTime, signingCAChecksum, persist, bundleFor)
+				meta, err := ObjectMeta(namespace, "test", config, false, lastRotationInitiationTime, validUntilTime, signingCAChecksum, persist, bundleFor, "", nil)
 				Expect(err).NotTo(HaveOccurred())
 
 				labels := map[string]string{
@@ -322,12 +419,13 @@ var _ = Describe("Manager", func() {
 			Expect(Secret(objectMeta, data)).To(Equal(&corev1.Secret{
 				ObjectMeta: objectMeta,
 				Data:       data,
-				Type:       corev1.SecretTypeOpaque,
+				Type:       expectedType,
 				Immutable:  pointer.Bool(true),
 			}))
 		},
 
 		Entry("regular secret", map[string][]byte{"some": []byte("data")}, corev1.SecretTypeOpaque),
-		Entry("tls secret", map[string][]byte{"tls.key": nil, "tls.crt": nil}, corev1.SecretTypeTLS),
+		Entry("tls secret", map[string][]byte{"tls.key": []byte("key"), "tls.crt": []byte("crt")}, corev1.SecretTypeTLS),
+		Entry("ssh-auth secret", map[string][]byte{"ssh-privatekey": []byte("key")}, corev1.SecretTypeSSHAuth),
 	)
 })
@@ -0,0 +1,65 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// legacySecretNameToConfigName maps the literal, non-checksummed names of secrets which were created before the
+// introduction of the secrets manager to the name of the config which manages them nowadays. It mirrors the legacy
+// secret names handled by keepExistingSecretsIfNeeded.
+var legacySecretNameToConfigName = map[string]string{
+	"kube-apiserver-basic-auth":            "kube-apiserver-basic-auth",
+	"monitoring-ingress-credentials":       "observability-ingress",
+	"monitoring-ingress-credentials-users": "observability-ingress-users",
+	"static-token":                         "kube-apiserver-static-token",
+	"ssh-keypair":                          "ssh-keypair",
+	"ssh-keypair.old":                      "ssh-keypair",
+	"etcd-encryption-secret":               "kube-apiserver-etcd-encryption-key",
+}
+
+// Migrate relabels and immutabilizes any of the well-known, pre-secrets-manager legacy secrets (see
+// legacySecretNameToConfigName) that still exist in the manager's namespace without the standard managed-by/identity
+// labels, e.g. because their config was never passed through Generate yet, so keepExistingSecretsIfNeeded never got
+// a chance to adopt them. This prevents them from leaking as orphaned, unmanaged objects.
+func (m *manager) Migrate(ctx context.Context) error {
+	for legacyName, configName := range legacySecretNameToConfigName {
+		secret := &corev1.Secret{}
+		if err := m.client.Get(ctx, kutil.Key(m.namespace, legacyName), secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		if secret.Labels[LabelKeyManagedBy] == LabelValueSecretsManager {
+			// Already adopted, either by this manager identity or another one; leave foreign identities alone to
+			// avoid stealing ownership of a secret another manager instance is actively managing.
+			continue
+		}
+
+		if err := m.relabelAndImmutabilizeLegacySecret(ctx, secret, configName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
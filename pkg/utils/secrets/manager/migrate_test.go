@@ -0,0 +1,120 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Migrate", func() {
+	const (
+		testIdentity = "test"
+		namespace    = "shoot--foo--bar"
+	)
+
+	var (
+		ctx = context.TODO()
+
+		m          *manager
+		fakeClient client.Client
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetesscheme.Scheme).Build()
+
+		mgr, err := New(ctx, logr.Discard(), clock.NewFakeClock(time.Time{}), fakeClient, namespace, testIdentity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+	})
+
+	Describe("#Migrate", func() {
+		It("should do nothing if no legacy secrets exist", func() {
+			Expect(m.Migrate(ctx)).To(Succeed())
+		})
+
+		It("should relabel and immutabilize known legacy secrets found in the namespace", func() {
+			By("creating a legacy ssh-keypair secret")
+			sshKeypair := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "ssh-keypair", Namespace: namespace},
+				Data:       map[string][]byte{"id_rsa": []byte("key")},
+			}
+			Expect(fakeClient.Create(ctx, sshKeypair)).To(Succeed())
+
+			By("creating a legacy static-token secret")
+			staticToken := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "static-token", Namespace: namespace},
+			}
+			Expect(fakeClient.Create(ctx, staticToken)).To(Succeed())
+
+			By("migrating")
+			Expect(m.Migrate(ctx)).To(Succeed())
+
+			By("verifying the ssh-keypair secret was adopted")
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(sshKeypair), sshKeypair)).To(Succeed())
+			Expect(sshKeypair.Immutable).To(PointTo(BeTrue()))
+			Expect(sshKeypair.Labels).To(Equal(map[string]string{
+				"name":                          "ssh-keypair",
+				"managed-by":                    "secrets-manager",
+				"manager-identity":              testIdentity,
+				"persist":                       "true",
+				"last-rotation-initiation-time": "",
+			}))
+
+			By("verifying the static-token secret was adopted")
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(staticToken), staticToken)).To(Succeed())
+			Expect(staticToken.Immutable).To(PointTo(BeTrue()))
+			Expect(staticToken.Labels).To(Equal(map[string]string{
+				"name":                          "kube-apiserver-static-token",
+				"managed-by":                    "secrets-manager",
+				"manager-identity":              testIdentity,
+				"persist":                       "true",
+				"last-rotation-initiation-time": "",
+			}))
+		})
+
+		It("should not touch a legacy secret which is already managed by another identity", func() {
+			foreign := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ssh-keypair",
+					Namespace: namespace,
+					Labels: map[string]string{
+						LabelKeyName:            "ssh-keypair",
+						LabelKeyManagedBy:       LabelValueSecretsManager,
+						LabelKeyManagerIdentity: "other",
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, foreign)).To(Succeed())
+
+			Expect(m.Migrate(ctx)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(foreign), foreign)).To(Succeed())
+			Expect(foreign.Labels).To(HaveKeyWithValue(LabelKeyManagerIdentity, "other"))
+			Expect(foreign.Immutable).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,80 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/gardener/gardener/pkg/utils/flow"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PruneBundles lists all bundle secrets for the CA with the given name (i.e. secrets labeled 'bundle-for: <caName>'
+// and managed by this identity), sorts them by their 'issued-at-time' label (newest first), and deletes all but the
+// newest 'keep' of them. The bundle secret currently referenced in the internal store, if any, is never deleted,
+// regardless of where it falls in the sort order.
+func (m *manager) PruneBundles(ctx context.Context, caName string, keep int) error {
+	secretList := &corev1.SecretList{}
+	if err := m.client.List(ctx, secretList, client.InNamespace(m.namespace), client.MatchingLabels{
+		LabelKeyBundleFor:       caName,
+		LabelKeyManagedBy:       LabelValueSecretsManager,
+		LabelKeyManagerIdentity: m.identity,
+	}); err != nil {
+		return err
+	}
+
+	secrets := secretList.Items
+	sort.Slice(secrets, func(i, j int) bool {
+		return issuedAtUnix(secrets[i]) > issuedAtUnix(secrets[j])
+	})
+
+	namesToKeep := sets.NewString()
+	for i := 0; i < len(secrets) && namesToKeep.Len() < keep; i++ {
+		namesToKeep.Insert(secrets[i].Name)
+	}
+
+	if storeSecrets, found := m.getFromStore(m.namespace, caName); found && storeSecrets.bundle != nil {
+		namesToKeep.Insert(storeSecrets.bundle.obj.Name)
+	}
+
+	var fns []flow.TaskFn
+
+	for i := range secrets {
+		secret := secrets[i]
+		if namesToKeep.Has(secret.Name) {
+			continue
+		}
+
+		fns = append(fns, func(ctx context.Context) error {
+			m.logger.Info("Pruning stale bundle secret", "namespace", secret.Namespace, "name", secret.Name)
+			return client.IgnoreNotFound(m.client.Delete(ctx, &secret))
+		})
+	}
+
+	return flow.Parallel(fns...)(ctx)
+}
+
+func issuedAtUnix(secret corev1.Secret) int64 {
+	unix, err := strconv.ParseInt(secret.Labels[LabelKeyIssuedAtTime], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return unix
+}
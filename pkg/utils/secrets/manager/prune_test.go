@@ -0,0 +1,159 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("PruneBundles", func() {
+	const (
+		testIdentity = "test"
+		namespace    = "shoot--foo--bar"
+		caName       = "ca"
+	)
+
+	var (
+		ctx = context.TODO()
+
+		m          *manager
+		fakeClient client.Client
+		fakeClock  = clock.NewFakeClock(time.Time{})
+
+		caConfig *secretutils.CertificateSecretConfig
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetesscheme.Scheme).Build()
+
+		mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, testIdentity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+
+		caConfig = &secretutils.CertificateSecretConfig{
+			Name:       caName,
+			CommonName: caName,
+			CertType:   secretutils.CACert,
+		}
+	})
+
+	listBundleSecrets := func() []corev1.Secret {
+		secretList := &corev1.SecretList{}
+		Expect(m.client.List(ctx, secretList, client.InNamespace(namespace), client.MatchingLabels{LabelKeyBundleFor: caName})).To(Succeed())
+		return secretList.Items
+	}
+
+	rotateCA := func() {
+		fakeClock.Step(time.Hour)
+		mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, testIdentity, map[string]time.Time{caName: fakeClock.Now()})
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+		_, err = m.Generate(ctx, caConfig, Rotate(KeepOld))
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Describe("#PruneBundles", func() {
+		It("should do nothing because there are no bundle secrets", func() {
+			Expect(m.PruneBundles(ctx, caName, 2)).To(Succeed())
+			Expect(listBundleSecrets()).To(BeEmpty())
+		})
+
+		It("should keep only the newest 'keep' bundle secrets, always retaining the one referenced in the store", func() {
+			By("generating initial CA and bundle")
+			_, err := m.Generate(ctx, caConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("rotating the CA repeatedly to accumulate multiple bundle secrets")
+			for i := 0; i < 4; i++ {
+				rotateCA()
+			}
+
+			Expect(listBundleSecrets()).To(HaveLen(5))
+
+			secretInfos, found := m.getFromStore(namespace, caName)
+			Expect(found).To(BeTrue())
+			currentBundleName := secretInfos.bundle.obj.Name
+
+			By("pruning down to the newest 2")
+			Expect(m.PruneBundles(ctx, caName, 2)).To(Succeed())
+
+			remaining := listBundleSecrets()
+			Expect(remaining).To(HaveLen(2))
+
+			var remainingNames []string
+			for _, secret := range remaining {
+				remainingNames = append(remainingNames, secret.Name)
+			}
+			Expect(remainingNames).To(ContainElement(currentBundleName))
+		})
+
+		It("should not delete the bundle referenced in the store even if it is not among the newest 'keep'", func() {
+			By("generating initial CA and bundle")
+			_, err := m.Generate(ctx, caConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			secretInfos, found := m.getFromStore(namespace, caName)
+			Expect(found).To(BeTrue())
+			currentBundleName := secretInfos.bundle.obj.Name
+
+			By("creating additional, newer-looking bundle secrets not known to the store")
+			for i := 0; i < 4; i++ {
+				fakeClock.Step(time.Hour)
+
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      caName + nameSuffixBundle + "-stray-" + strconv.Itoa(i),
+						Namespace: namespace,
+						Labels: map[string]string{
+							LabelKeyBundleFor:       caName,
+							LabelKeyManagedBy:       LabelValueSecretsManager,
+							LabelKeyManagerIdentity: testIdentity,
+							LabelKeyIssuedAtTime:    unixTime(fakeClock.Now()),
+						},
+					},
+				}
+				Expect(m.client.Create(ctx, secret)).To(Succeed())
+			}
+
+			Expect(listBundleSecrets()).To(HaveLen(5))
+
+			By("pruning down to the newest 2")
+			Expect(m.PruneBundles(ctx, caName, 2)).To(Succeed())
+
+			remaining := listBundleSecrets()
+			Expect(remaining).To(HaveLen(3)) // newest 2 stray bundles + the one still referenced in the store
+
+			var remainingNames []string
+			for _, secret := range remaining {
+				remainingNames = append(remainingNames, secret.Name)
+			}
+			Expect(remainingNames).To(ContainElement(currentBundleName))
+		})
+	})
+})
@@ -0,0 +1,115 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// errReadOnly is returned by every write method of a manager constructed via NewReadOnly.
+var errReadOnly = errors.New("manager is read-only")
+
+// readOnlyManager wraps an Interface and rejects every write operation with errReadOnly without touching the API
+// server, while delegating every other method to the wrapped manager unchanged.
+type readOnlyManager struct {
+	Interface
+}
+
+var _ Interface = &readOnlyManager{}
+
+// NewReadOnly returns a manager for secrets in the given namespace exactly like New, except that its write methods
+// (Generate, Cleanup, CompleteRotation, WaitForCleanup, GenerateCRL, RotateCA, RotatePassword, Flush, PruneBundles,
+// and Migrate) return an error without touching the API server. This is intended for audit/reporting tooling built
+// on top of Get, List, Expiring, NeedsRotation, Verify, Refresh and Report, which must never mutate cluster state by
+// accident.
+func NewReadOnly(
+	ctx context.Context,
+	logger logr.Logger,
+	clock clock.Clock,
+	c client.Client,
+	namespace string,
+	identity string,
+	secretNamesToTimes map[string]time.Time,
+	namePrefix ...string,
+) (
+	Interface,
+	error,
+) {
+	m, err := New(ctx, logger, clock, c, namespace, identity, secretNamesToTimes, namePrefix...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &readOnlyManager{Interface: m}, nil
+}
+
+// Generate implements Interface.
+func (r *readOnlyManager) Generate(context.Context, secretutils.ConfigInterface, ...GenerateOption) (*corev1.Secret, error) {
+	return nil, errReadOnly
+}
+
+// Cleanup implements Interface.
+func (r *readOnlyManager) Cleanup(context.Context) error {
+	return errReadOnly
+}
+
+// CompleteRotation implements Interface.
+func (r *readOnlyManager) CompleteRotation(context.Context, string, time.Duration) error {
+	return errReadOnly
+}
+
+// WaitForCleanup implements Interface.
+func (r *readOnlyManager) WaitForCleanup(context.Context, string, ConsumersMigratedFunc) (bool, error) {
+	return false, errReadOnly
+}
+
+// GenerateCRL implements Interface.
+func (r *readOnlyManager) GenerateCRL(context.Context, string, []RevokedCert) (*corev1.Secret, error) {
+	return nil, errReadOnly
+}
+
+// RotateCA implements Interface.
+func (r *readOnlyManager) RotateCA(context.Context, string) ([]*corev1.Secret, error) {
+	return nil, errReadOnly
+}
+
+// RotatePassword implements Interface.
+func (r *readOnlyManager) RotatePassword(context.Context, string, ...GenerateOption) (*corev1.Secret, error) {
+	return nil, errReadOnly
+}
+
+// Flush implements Interface.
+func (r *readOnlyManager) Flush(context.Context) error {
+	return errReadOnly
+}
+
+// PruneBundles implements Interface.
+func (r *readOnlyManager) PruneBundles(context.Context, string, int) error {
+	return errReadOnly
+}
+
+// Migrate implements Interface.
+func (r *readOnlyManager) Migrate(context.Context) error {
+	return errReadOnly
+}
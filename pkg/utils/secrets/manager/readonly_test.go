@@ -0,0 +1,127 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ReadOnly", func() {
+	var (
+		ctx        = context.TODO()
+		namespace  = "shoot--foo--bar"
+		identity   = "test"
+		fakeClient client.Client
+		fakeClock  = clock.NewFakeClock(time.Time{})
+
+		m Interface
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetesscheme.Scheme).Build()
+
+		writableManager, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		config := &secretutils.BasicAuthSecretConfig{
+			Name:           "basic-auth",
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "admin",
+			PasswordLength: 32,
+		}
+		_, err = writableManager.Generate(ctx, config)
+		Expect(err).NotTo(HaveOccurred())
+
+		m, err = NewReadOnly(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(m.Refresh(ctx)).To(Succeed())
+	})
+
+	It("should allow reads", func() {
+		_, ok := m.Get("basic-auth")
+		Expect(ok).To(BeTrue())
+
+		Expect(m.List()).To(HaveLen(1))
+		Expect(m.Expiring(time.Hour)).To(BeEmpty())
+		Expect(m.NeedsRotation(time.Hour)).To(BeEmpty())
+
+		report, err := m.Report(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report).To(HaveLen(1))
+	})
+
+	It("should reject Generate", func() {
+		config := &secretutils.BasicAuthSecretConfig{
+			Name:           "other",
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "admin",
+			PasswordLength: 32,
+		}
+		_, err := m.Generate(ctx, config)
+		Expect(err).To(MatchError(ContainSubstring("read-only")))
+	})
+
+	It("should reject Cleanup", func() {
+		Expect(m.Cleanup(ctx)).To(MatchError(ContainSubstring("read-only")))
+	})
+
+	It("should reject CompleteRotation", func() {
+		Expect(m.CompleteRotation(ctx, "basic-auth", time.Hour)).To(MatchError(ContainSubstring("read-only")))
+	})
+
+	It("should reject WaitForCleanup", func() {
+		_, err := m.WaitForCleanup(ctx, "basic-auth", func(context.Context) (bool, error) { return true, nil })
+		Expect(err).To(MatchError(ContainSubstring("read-only")))
+	})
+
+	It("should reject GenerateCRL", func() {
+		_, err := m.GenerateCRL(ctx, "basic-auth", nil)
+		Expect(err).To(MatchError(ContainSubstring("read-only")))
+	})
+
+	It("should reject RotateCA", func() {
+		_, err := m.RotateCA(ctx, "basic-auth")
+		Expect(err).To(MatchError(ContainSubstring("read-only")))
+	})
+
+	It("should reject RotatePassword", func() {
+		_, err := m.RotatePassword(ctx, "basic-auth")
+		Expect(err).To(MatchError(ContainSubstring("read-only")))
+	})
+
+	It("should reject Flush", func() {
+		Expect(m.Flush(ctx)).To(MatchError(ContainSubstring("read-only")))
+	})
+
+	It("should reject PruneBundles", func() {
+		Expect(m.PruneBundles(ctx, "basic-auth", 1)).To(MatchError(ContainSubstring("read-only")))
+	})
+
+	It("should reject Migrate", func() {
+		Expect(m.Migrate(ctx)).To(MatchError(ContainSubstring("read-only")))
+	})
+})
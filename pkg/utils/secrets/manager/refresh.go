@@ -0,0 +1,89 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Refresh re-lists all secrets matching this manager's identity labels from the cluster and rebuilds the internal
+// store from them, discarding whatever was tracked before. This is useful to reconcile the store after it may have
+// diverged from the cluster, e.g. because another controller or a manual edit changed or deleted a secret. Unlike
+// New, which only reconstructs the last-rotation-initiation-time bookkeeping, Refresh rebuilds the full
+// current/old/bundle classification, so subsequent Generate calls observe the live state of the cluster.
+func (m *manager) Refresh(ctx context.Context) error {
+	secretList, err := m.listSecrets(ctx)
+	if err != nil {
+		return err
+	}
+
+	byName := map[string][]corev1.Secret{}
+	byBundleFor := map[string][]corev1.Secret{}
+
+	for _, secret := range secretList.Items {
+		if bundleFor, ok := secret.Labels[LabelKeyBundleFor]; ok {
+			byBundleFor[bundleFor] = append(byBundleFor[bundleFor], secret)
+			continue
+		}
+		byName[secret.Labels[LabelKeyName]] = append(byName[secret.Labels[LabelKeyName]], secret)
+	}
+
+	newStore := make(secretStore, len(byName))
+
+	for name, secrets := range byName {
+		sortByCreationTimestampDescending(secrets)
+
+		current, err := computeSecretInfo(secrets[0].DeepCopy())
+		if err != nil {
+			return err
+		}
+		infos := secretInfos{current: current}
+
+		if len(secrets) > 1 {
+			old, err := computeSecretInfo(secrets[1].DeepCopy())
+			if err != nil {
+				return err
+			}
+			infos.old = &old
+		}
+
+		if bundleSecrets, ok := byBundleFor[name]; ok && len(bundleSecrets) > 0 {
+			sortByCreationTimestampDescending(bundleSecrets)
+
+			bundle, err := computeSecretInfo(bundleSecrets[0].DeepCopy())
+			if err != nil {
+				return err
+			}
+			infos.bundle = &bundle
+		}
+
+		newStore[secretStoreKey{namespace: m.namespace, name: name}] = infos
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.store = newStore
+
+	return nil
+}
+
+func sortByCreationTimestampDescending(secrets []corev1.Secret) {
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[j].CreationTimestamp.Time.Before(secrets[i].CreationTimestamp.Time)
+	})
+}
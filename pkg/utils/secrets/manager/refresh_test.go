@@ -0,0 +1,152 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Refresh", func() {
+	var (
+		ctx        = context.TODO()
+		namespace  = "shoot--foo--bar"
+		identity   = "test"
+		fakeClient client.Client
+		fakeClock  = clock.NewFakeClock(time.Time{})
+
+		m *manager
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetesscheme.Scheme).Build()
+
+		mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+	})
+
+	It("should pick up a secret created behind the manager's back", func() {
+		Expect(m.store).To(BeEmpty())
+
+		Expect(fakeClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "basic-auth-foo",
+				Namespace: namespace,
+				Labels: map[string]string{
+					LabelKeyName:            "basic-auth",
+					LabelKeyManagedBy:       LabelValueSecretsManager,
+					LabelKeyManagerIdentity: identity,
+				},
+			},
+			Data: map[string][]byte{"username": []byte("admin")},
+		})).To(Succeed())
+
+		Expect(m.Refresh(ctx)).To(Succeed())
+
+		infos, found := m.getFromStore(namespace, "basic-auth")
+		Expect(found).To(BeTrue())
+		Expect(infos.current.obj.Name).To(Equal("basic-auth-foo"))
+		Expect(infos.old).To(BeNil())
+		Expect(infos.bundle).To(BeNil())
+	})
+
+	It("should forget a secret deleted behind the manager's back", func() {
+		secret, err := m.Generate(ctx, &secretutils.BasicAuthSecretConfig{
+			Name:           "basic-auth",
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "admin",
+			PasswordLength: 32,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, found := m.getFromStore(namespace, "basic-auth")
+		Expect(found).To(BeTrue())
+
+		Expect(fakeClient.Delete(ctx, secret)).To(Succeed())
+
+		Expect(m.Refresh(ctx)).To(Succeed())
+
+		_, found = m.getFromStore(namespace, "basic-auth")
+		Expect(found).To(BeFalse())
+	})
+
+	It("should correctly re-associate the newest secret as current, the next-newest as old, and the bundle via its label", func() {
+		olderTime := metav1.NewTime(fakeClock.Now())
+		newerTime := metav1.NewTime(fakeClock.Now().Add(time.Hour))
+
+		Expect(fakeClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ca-old",
+				Namespace: namespace,
+				Labels: map[string]string{
+					LabelKeyName:            "ca",
+					LabelKeyManagedBy:       LabelValueSecretsManager,
+					LabelKeyManagerIdentity: identity,
+				},
+				CreationTimestamp: olderTime,
+			},
+		})).To(Succeed())
+
+		Expect(fakeClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ca-new",
+				Namespace: namespace,
+				Labels: map[string]string{
+					LabelKeyName:            "ca",
+					LabelKeyManagedBy:       LabelValueSecretsManager,
+					LabelKeyManagerIdentity: identity,
+				},
+				CreationTimestamp: newerTime,
+			},
+		})).To(Succeed())
+
+		Expect(fakeClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ca-bundle",
+				Namespace: namespace,
+				Labels: map[string]string{
+					LabelKeyName:            "ca-bundle",
+					LabelKeyManagedBy:       LabelValueSecretsManager,
+					LabelKeyManagerIdentity: identity,
+					LabelKeyBundleFor:       "ca",
+				},
+				CreationTimestamp: newerTime,
+			},
+		})).To(Succeed())
+
+		Expect(m.Refresh(ctx)).To(Succeed())
+
+		infos, found := m.getFromStore(namespace, "ca")
+		Expect(found).To(BeTrue())
+		Expect(infos.current.obj.Name).To(Equal("ca-new"))
+		Expect(infos.old).NotTo(BeNil())
+		Expect(infos.old.obj.Name).To(Equal("ca-old"))
+		Expect(infos.bundle).NotTo(BeNil())
+		Expect(infos.bundle.obj.Name).To(Equal("ca-bundle"))
+	})
+})
@@ -0,0 +1,82 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+
+	"github.com/gardener/gardener/pkg/utils"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Status combines a Summary from the internal store with a live lookup against the cluster, in order to surface
+// drift between what the manager believes it manages and what is actually present in the cluster.
+type Status struct {
+	Summary
+
+	// ExistsInCluster specifies whether the current secret could still be found in the cluster.
+	ExistsInCluster bool
+	// MatchesStore specifies whether the data of the secret found in the cluster matches the data tracked in the
+	// internal store. It is always false if ExistsInCluster is false.
+	MatchesStore bool
+	// Expiring specifies whether the secret is due for auto-renewal, i.e. whether 80% of its validity has elapsed
+	// or it expires within the next 10 days. It is always false for secrets without a configured validity.
+	Expiring bool
+	// LastRotationReason is the reason the most recent Generate call for this secret decided to create a new
+	// secret. It is empty if Generate has not (re-)created this secret since the manager was created.
+	LastRotationReason RotationReason
+}
+
+// Report returns a Status for every secret tracked by the internal store, combining the data returned by List with a
+// live Get call against the cluster for each secret's current variant. Unlike List, it does perform calls to the API
+// server, and is meant to back operator-facing status commands.
+func (m *manager) Report(ctx context.Context) ([]Status, error) {
+	reports := make([]Status, 0, len(m.List()))
+
+	for _, summary := range m.List() {
+		infos, found := m.getFromStore(summary.Namespace, summary.Name)
+		if !found {
+			continue
+		}
+
+		expiring, err := m.mustAutoRenewSecret(infos.current.obj.Labels[LabelKeyIssuedAtTime], infos.current.obj.Labels[LabelKeyValidUntilTime])
+		if err != nil {
+			return nil, err
+		}
+
+		status := Status{
+			Summary:            summary,
+			Expiring:           expiring,
+			LastRotationReason: m.getLastRotationReason(summary.Name),
+		}
+
+		liveSecret := &corev1.Secret{}
+		if err := m.client.Get(ctx, kutil.Key(infos.current.obj.Namespace, infos.current.obj.Name), liveSecret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+		} else {
+			status.ExistsInCluster = true
+			status.MatchesStore = utils.ComputeSecretChecksum(liveSecret.Data) == infos.current.dataChecksum
+		}
+
+		reports = append(reports, status)
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/gardener/gardener/pkg/utils"
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Report", func() {
+	var (
+		ctx        = context.TODO()
+		namespace  = "shoot--foo--bar"
+		identity   = "test"
+		fakeClient client.Client
+		fakeClock  = clock.NewFakeClock(time.Time{})
+
+		m *manager
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetesscheme.Scheme).Build()
+
+		mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+	})
+
+	It("should flag a freshly created, untampered, non-expiring secret as in sync", func() {
+		_, err := m.Generate(ctx, &secretutils.BasicAuthSecretConfig{
+			Name:           "basic-auth",
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "admin",
+			PasswordLength: 32,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		report, err := m.Report(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report).To(HaveLen(1))
+		Expect(report[0].Name).To(Equal("basic-auth"))
+		Expect(report[0].ExistsInCluster).To(BeTrue())
+		Expect(report[0].MatchesStore).To(BeTrue())
+		Expect(report[0].Expiring).To(BeFalse())
+		Expect(report[0].LastRotationReason).To(Equal(RotationReasonFirstGeneration))
+	})
+
+	It("should flag a secret that was deleted from the cluster behind the manager's back", func() {
+		secret, err := m.Generate(ctx, &secretutils.BasicAuthSecretConfig{
+			Name:           "basic-auth",
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "admin",
+			PasswordLength: 32,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeClient.Delete(ctx, secret)).To(Succeed())
+
+		report, err := m.Report(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report).To(HaveLen(1))
+		Expect(report[0].ExistsInCluster).To(BeFalse())
+		Expect(report[0].MatchesStore).To(BeFalse())
+	})
+
+	It("should flag a secret whose live data was tampered with", func() {
+		secret, err := m.Generate(ctx, &secretutils.BasicAuthSecretConfig{
+			Name:           "basic-auth",
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "admin",
+			PasswordLength: 32,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		liveSecret := &corev1.Secret{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), liveSecret)).To(Succeed())
+		patch := client.MergeFrom(liveSecret.DeepCopy())
+		liveSecret.Data["username"] = []byte("tampered")
+		Expect(fakeClient.Patch(ctx, liveSecret, patch)).To(Succeed())
+
+		report, err := m.Report(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report).To(HaveLen(1))
+		Expect(report[0].ExistsInCluster).To(BeTrue())
+		Expect(report[0].MatchesStore).To(BeFalse())
+	})
+
+	It("should flag a secret that is due for auto-renewal", func() {
+		_, err := m.Generate(ctx, &secretutils.CertificateSecretConfig{
+			Name:       "soon-to-expire",
+			CommonName: "soon-to-expire",
+			CertType:   secretutils.CACert,
+			Clock:      fakeClock,
+			Validity:   utils.DurationPtr(2 * 24 * time.Hour),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		report, err := m.Report(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report).To(HaveLen(1))
+		Expect(report[0].Expiring).To(BeTrue())
+	})
+
+	It("should record the reason of the most recent rotation", func() {
+		config := &secretutils.BasicAuthSecretConfig{
+			Name:           "basic-auth",
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "admin",
+			PasswordLength: 32,
+		}
+		_, err := m.Generate(ctx, config)
+		Expect(err).NotTo(HaveOccurred())
+
+		config.Username = "changed"
+		_, err = m.Generate(ctx, config)
+		Expect(err).NotTo(HaveOccurred())
+
+		report, err := m.Report(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report).To(HaveLen(1))
+		Expect(report[0].LastRotationReason).To(Equal(RotationReasonConfigChange))
+	})
+})
@@ -0,0 +1,87 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// registerConfig records the ConfigInterface and GenerateOptions that the config with the given name was last
+// generated with, so that a future RotateCA call can replay the same Generate call for it. If signedByInternalCA is
+// set, it additionally records the config as a dependent of the given CA name.
+func (m *manager) registerConfig(config secretutils.ConfigInterface, opts []GenerateOption, signedByInternalCA bool, caName *string) {
+	m.dependentsLock.Lock()
+	defer m.dependentsLock.Unlock()
+
+	m.configs[config.GetName()] = dependentRegistration{config: config, opts: opts}
+
+	if signedByInternalCA && caName != nil {
+		if m.caDependents[*caName] == nil {
+			m.caDependents[*caName] = make(map[string]struct{})
+		}
+		m.caDependents[*caName][config.GetName()] = struct{}{}
+	}
+}
+
+// RotateCA force-regenerates the CA secret with the given name, and then re-signs every leaf config that was last
+// generated with SignedByCA(caName, ...) against the new CA, replaying the exact Generate call (config and options)
+// each was last invoked with. It returns every secret it (re-)generated, starting with the CA itself.
+//
+// RotateCA only knows about configs that have already been generated at least once via this manager instance; it
+// returns an error if the CA itself was never generated through it. It is not transactional: if re-signing a
+// dependent fails, the CA and any dependents already re-signed keep their new state, and the error identifies which
+// dependent failed so the caller can retry.
+func (m *manager) RotateCA(ctx context.Context, caName string) ([]*corev1.Secret, error) {
+	m.dependentsLock.Lock()
+	caRegistration, found := m.configs[caName]
+	dependentNames := make([]string, 0, len(m.caDependents[caName]))
+	for name := range m.caDependents[caName] {
+		dependentNames = append(dependentNames, name)
+	}
+	m.dependentsLock.Unlock()
+
+	if !found {
+		return nil, fmt.Errorf("no configuration registered for CA %q, it must be generated via Generate before it can be rotated with RotateCA", caName)
+	}
+
+	caSecret, err := m.Generate(ctx, caRegistration.config, append(append([]GenerateOption{}, caRegistration.opts...), ForceRegenerate())...)
+	if err != nil {
+		return nil, fmt.Errorf("failed rotating CA %q: %w", caName, err)
+	}
+
+	regenerated := []*corev1.Secret{caSecret}
+
+	for _, name := range dependentNames {
+		m.dependentsLock.Lock()
+		registration, found := m.configs[name]
+		m.dependentsLock.Unlock()
+		if !found {
+			continue
+		}
+
+		secret, err := m.Generate(ctx, registration.config, append(append([]GenerateOption{}, registration.opts...), ForceRegenerate())...)
+		if err != nil {
+			return nil, fmt.Errorf("failed re-signing dependent %q of CA %q: %w", name, caName, err)
+		}
+		regenerated = append(regenerated, secret)
+	}
+
+	return regenerated, nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("RotateCA", func() {
+	const (
+		identity  = "test"
+		namespace = "shoot--foo--bar"
+		caName    = "ca"
+	)
+
+	var (
+		ctx = context.TODO()
+
+		fakeClient client.Client
+		fakeClock  = clock.NewFakeClock(time.Time{})
+
+		m            *manager
+		caConfig     *secretutils.CertificateSecretConfig
+		serverConfig *secretutils.CertificateSecretConfig
+		clientConfig *secretutils.CertificateSecretConfig
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetesscheme.Scheme).Build()
+
+		mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+
+		caConfig = &secretutils.CertificateSecretConfig{
+			Name:       caName,
+			CommonName: caName,
+			CertType:   secretutils.CACert,
+		}
+		serverConfig = &secretutils.CertificateSecretConfig{
+			Name:                        "server",
+			CommonName:                  "server",
+			CertType:                    secretutils.ServerCert,
+			SkipPublishingCACertificate: true,
+		}
+		clientConfig = &secretutils.CertificateSecretConfig{
+			Name:                        "client",
+			CommonName:                  "client",
+			CertType:                    secretutils.ClientCert,
+			SkipPublishingCACertificate: true,
+		}
+	})
+
+	It("should return an error if the CA was never generated via this manager instance", func() {
+		_, err := m.RotateCA(ctx, caName)
+		Expect(err).To(MatchError(ContainSubstring("no configuration registered for CA")))
+	})
+
+	It("should rotate the CA and re-sign all of its registered dependents", func() {
+		By("generating CA and two dependents signed by it")
+		caSecret, err := m.Generate(ctx, caConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		serverSecret, err := m.Generate(ctx, serverConfig, SignedByCA(caName, UseCurrentCA))
+		Expect(err).NotTo(HaveOccurred())
+
+		clientSecret, err := m.Generate(ctx, clientConfig, SignedByCA(caName))
+		Expect(err).NotTo(HaveOccurred())
+
+		By("rotating the CA")
+		regenerated, err := m.RotateCA(ctx, caName)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("verifying the CA and both dependents were regenerated")
+		Expect(regenerated).To(HaveLen(3))
+
+		newCASecret, found := m.Get(caName)
+		Expect(found).To(BeTrue())
+		Expect(newCASecret).NotTo(Equal(caSecret))
+
+		newServerSecret, found := m.Get("server")
+		Expect(found).To(BeTrue())
+		Expect(newServerSecret).NotTo(Equal(serverSecret))
+		Expect(newServerSecret.Annotations["signed-by-ca-checksum"]).To(Equal(newServerSecret.Labels["checksum-of-signing-ca"]))
+		Expect(newServerSecret.Labels["checksum-of-signing-ca"]).NotTo(Equal(serverSecret.Labels["checksum-of-signing-ca"]))
+
+		newClientSecret, found := m.Get("client")
+		Expect(found).To(BeTrue())
+		Expect(newClientSecret).NotTo(Equal(clientSecret))
+		Expect(newClientSecret.Annotations["signed-by-ca-checksum"]).To(Equal(newClientSecret.Labels["checksum-of-signing-ca"]))
+		Expect(newClientSecret.Labels["checksum-of-signing-ca"]).NotTo(Equal(clientSecret.Labels["checksum-of-signing-ca"]))
+	})
+})
@@ -0,0 +1,48 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// RotatePassword force-regenerates only the password of the BasicAuthSecretConfig registered under the given name,
+// keeping its username and format unchanged, by replaying the exact Generate call (config and options) it was last
+// invoked with, plus ForceRegenerate and any additionally given opts. Pass Rotate(KeepOld) to retain the outgoing
+// password for a grace period, just like any other Generate call.
+//
+// RotatePassword only knows about configs that have already been generated at least once via this manager instance;
+// it returns an error if the name was never generated through it, or if it was generated with a config other than a
+// BasicAuthSecretConfig.
+func (m *manager) RotatePassword(ctx context.Context, name string, opts ...GenerateOption) (*corev1.Secret, error) {
+	m.dependentsLock.Lock()
+	registration, found := m.configs[name]
+	m.dependentsLock.Unlock()
+
+	if !found {
+		return nil, fmt.Errorf("no configuration registered for %q, it must be generated via Generate before its password can be rotated with RotatePassword", name)
+	}
+
+	if _, ok := registration.config.(*secretutils.BasicAuthSecretConfig); !ok {
+		return nil, fmt.Errorf("configuration registered for %q is a %T, not a *secrets.BasicAuthSecretConfig", name, registration.config)
+	}
+
+	return m.Generate(ctx, registration.config, append(append(append([]GenerateOption{}, registration.opts...), opts...), ForceRegenerate())...)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("RotatePassword", func() {
+	const (
+		identity  = "test"
+		namespace = "shoot--foo--bar"
+		name      = "basic-auth"
+	)
+
+	var (
+		ctx = context.TODO()
+
+		fakeClient client.Client
+		fakeClock  = clock.NewFakeClock(time.Time{})
+
+		m      *manager
+		config *secretutils.BasicAuthSecretConfig
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetesscheme.Scheme).Build()
+
+		mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+
+		config = &secretutils.BasicAuthSecretConfig{
+			Name:           name,
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "admin",
+			PasswordLength: 32,
+		}
+	})
+
+	It("should return an error if no configuration was ever generated via this manager instance", func() {
+		_, err := m.RotatePassword(ctx, name)
+		Expect(err).To(MatchError(ContainSubstring("no configuration registered for")))
+	})
+
+	It("should return an error if the registered configuration is not a BasicAuthSecretConfig", func() {
+		caConfig := &secretutils.CertificateSecretConfig{
+			Name:       name,
+			CommonName: name,
+			CertType:   secretutils.CACert,
+		}
+		_, err := m.Generate(ctx, caConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = m.RotatePassword(ctx, name)
+		Expect(err).To(MatchError(ContainSubstring("not a *secrets.BasicAuthSecretConfig")))
+	})
+
+	It("should force-regenerate the secret in place, keeping the username and name", func() {
+		By("generating the basic auth secret")
+		secret, err := m.Generate(ctx, config)
+		Expect(err).NotTo(HaveOccurred())
+		expectSecretWasCreated(ctx, fakeClient, secret)
+
+		By("rotating the password")
+		var reasons []RotationReason
+		rotated, err := m.RotatePassword(ctx, name, OnRotation(func(reason RotationReason) {
+			reasons = append(reasons, reason)
+		}))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(reasons).To(ConsistOf(RotationReasonForceRegenerate))
+		Expect(rotated.Name).To(Equal(secret.Name))
+		Expect(rotated.Data[secretutils.DataKeyUserName]).To(Equal([]byte(config.Username)))
+
+		By("verifying the secret was updated in the cluster rather than recreated")
+		foundSecret := &corev1.Secret{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), foundSecret)).To(Succeed())
+		Expect(foundSecret.Data).To(Equal(rotated.Data))
+	})
+
+	It("should keep the overwritten password available as the old secret when called with Rotate(KeepOld)", func() {
+		By("generating the basic auth secret")
+		secret, err := m.Generate(ctx, config)
+		Expect(err).NotTo(HaveOccurred())
+		expectSecretWasCreated(ctx, fakeClient, secret)
+
+		By("rotating the password with KeepOld")
+		_, err = m.RotatePassword(ctx, name, Rotate(KeepOld))
+		Expect(err).NotTo(HaveOccurred())
+
+		By("verifying internal store reflects changes")
+		secretInfos, found := m.getFromStore(namespace, name)
+		Expect(found).To(BeTrue())
+		Expect(secretInfos.old).NotTo(BeNil())
+		Expect(secretInfos.old.obj.Data).To(Equal(secret.Data))
+	})
+})
@@ -0,0 +1,53 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	mathrand "math/rand"
+	"sort"
+	"time"
+)
+
+// StaggerRotationInitiationTimes computes, for every given secret name, a rotation initiation time obtained by
+// subtracting a pseudo-random jitter (uniformly distributed within the secret's validity) from now. Feeding the
+// returned map into New's secretNamesToTimes parameter staggers the point in time at which each secret is considered
+// due for automatic renewal, so that many CAs/secrets sharing the same validity do not all become due for rotation
+// at the same instant (which would otherwise cause a thundering herd of reconciliations). Secret names are iterated
+// in sorted order to derive the jitter, so the returned times are reproducible across calls for a given seed and set
+// of validities, regardless of map iteration order. A non-positive validity is left unjittered, i.e. its rotation
+// initiation time is set to now.
+func StaggerRotationInitiationTimes(now time.Time, validities map[string]time.Duration, seed int64) map[string]time.Time {
+	names := make([]string, 0, len(validities))
+	for name := range validities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rnd := mathrand.New(mathrand.NewSource(seed))
+
+	result := make(map[string]time.Time, len(validities))
+	for _, name := range names {
+		validity := validities[name]
+		if validity <= 0 {
+			result[name] = now
+			continue
+		}
+
+		jitter := time.Duration(rnd.Int63n(int64(validity)))
+		result[name] = now.Add(-jitter)
+	}
+
+	return result
+}
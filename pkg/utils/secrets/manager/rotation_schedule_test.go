@@ -0,0 +1,70 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StaggerRotationInitiationTimes", func() {
+	var (
+		now        = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		validities map[string]time.Duration
+	)
+
+	BeforeEach(func() {
+		validities = map[string]time.Duration{
+			"ca-1": 30 * 24 * time.Hour,
+			"ca-2": 90 * 24 * time.Hour,
+			"ca-3": 24 * time.Hour,
+		}
+	})
+
+	It("should stagger every secret's rotation initiation time within its validity window", func() {
+		result := StaggerRotationInitiationTimes(now, validities, 1)
+
+		for name, validity := range validities {
+			Expect(result).To(HaveKey(name))
+			Expect(result[name]).To(BeTemporally(">", now.Add(-validity)))
+			Expect(result[name]).To(BeTemporally("<=", now))
+		}
+	})
+
+	It("should not stagger identically for every secret", func() {
+		result := StaggerRotationInitiationTimes(now, validities, 1)
+		Expect(result["ca-1"]).NotTo(Equal(result["ca-2"]))
+	})
+
+	It("should be reproducible for a fixed seed", func() {
+		first := StaggerRotationInitiationTimes(now, validities, 42)
+		second := StaggerRotationInitiationTimes(now, validities, 42)
+		Expect(first).To(Equal(second))
+	})
+
+	It("should compute a different spread for a different seed", func() {
+		first := StaggerRotationInitiationTimes(now, validities, 1)
+		second := StaggerRotationInitiationTimes(now, validities, 2)
+		Expect(first).NotTo(Equal(second))
+	})
+
+	It("should leave the initiation time at now for a non-positive validity", func() {
+		validities["ca-4"] = 0
+		result := StaggerRotationInitiationTimes(now, validities, 1)
+		Expect(result["ca-4"]).To(Equal(now))
+	})
+})
@@ -0,0 +1,119 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// snapshotVersion must be bumped whenever the snapshot format changes in an incompatible way. Snapshots read
+	// back with a different version are ignored so that New falls back to deriving the bookkeeping from the cluster.
+	snapshotVersion = 1
+	// snapshotDataKey is the key in the snapshot ConfigMap's Data map under which the marshalled snapshot is stored.
+	snapshotDataKey = "snapshot.json"
+	// snapshotConfigMapNamePrefix prefixes the name of the ConfigMap a manager instance uses to persist its
+	// bookkeeping across restarts.
+	snapshotConfigMapNamePrefix = "secrets-manager-snapshot-"
+)
+
+// secretSnapshot is the persisted representation of the lightweight bookkeeping a manager keeps for the secrets it
+// manages. It intentionally does not contain any secret data - only the metadata required to skip re-deriving it
+// from a full listing of the cluster's secrets on restart.
+type secretSnapshot struct {
+	Version int                            `json:"version"`
+	Secrets map[string]secretSnapshotEntry `json:"secrets"`
+}
+
+type secretSnapshotEntry struct {
+	LastRotationInitiationTime string `json:"lastRotationInitiationTime,omitempty"`
+	IssuedAtTime               string `json:"issuedAtTime,omitempty"`
+	ValidUntilTime             string `json:"validUntilTime,omitempty"`
+}
+
+func snapshotConfigMapName(identity string) string {
+	return snapshotConfigMapNamePrefix + identity
+}
+
+// Flush persists the manager's lightweight bookkeeping (rotation times and lifetime labels) to a manager-owned
+// ConfigMap so that a future call to New can reuse it instead of re-deriving it from a full listing of the
+// cluster's secrets. It does not persist any secret data. Callers are expected to call Flush after Generate calls
+// they want to survive a restart, e.g. at the end of a reconciliation.
+func (m *manager) Flush(ctx context.Context) error {
+	m.lock.Lock()
+	snapshot := secretSnapshot{
+		Version: snapshotVersion,
+		Secrets: make(map[string]secretSnapshotEntry, len(m.lastRotationInitiationTimes)),
+	}
+
+	for name, lastRotationInitiationTime := range m.lastRotationInitiationTimes {
+		entry := secretSnapshotEntry{LastRotationInitiationTime: lastRotationInitiationTime}
+
+		if secrets, ok := m.store[secretStoreKey{namespace: m.namespace, name: name}]; ok && secrets.current.obj != nil {
+			entry.IssuedAtTime = secrets.current.obj.Labels[LabelKeyIssuedAtTime]
+			entry.ValidUntilTime = secrets.current.obj.Labels[LabelKeyValidUntilTime]
+		}
+
+		snapshot.Secrets[name] = entry
+	}
+	m.lock.Unlock()
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: snapshotConfigMapName(m.identity), Namespace: m.namespace}}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, m.client, configMap, func() error {
+		metav1.SetMetaDataLabel(&configMap.ObjectMeta, LabelKeyManagedBy, LabelValueSecretsManager)
+		metav1.SetMetaDataLabel(&configMap.ObjectMeta, LabelKeyManagerIdentity, m.identity)
+		configMap.Data = map[string]string{snapshotDataKey: string(data)}
+		return nil
+	})
+	return err
+}
+
+// loadSnapshot reads back the ConfigMap written by Flush for this manager's identity. It returns (nil, nil) if no
+// snapshot exists yet, or if the persisted snapshot has an incompatible version.
+func (m *manager) loadSnapshot(ctx context.Context) (*secretSnapshot, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := m.client.Get(ctx, client.ObjectKey{Namespace: m.namespace, Name: snapshotConfigMapName(m.identity)}, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	snapshot := &secretSnapshot{}
+	if err := json.Unmarshal([]byte(configMap.Data[snapshotDataKey]), snapshot); err != nil {
+		m.logger.Info("Ignoring unreadable secrets manager snapshot", "configMap", client.ObjectKeyFromObject(configMap), "err", err)
+		return nil, nil
+	}
+
+	if snapshot.Version != snapshotVersion {
+		m.logger.Info("Ignoring secrets manager snapshot with incompatible version", "configMap", client.ObjectKeyFromObject(configMap), "snapshotVersion", snapshot.Version, "expectedVersion", snapshotVersion)
+		return nil, nil
+	}
+
+	return snapshot, nil
+}
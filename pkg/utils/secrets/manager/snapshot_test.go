@@ -0,0 +1,119 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Flush / snapshot", func() {
+	var (
+		ctx        = context.TODO()
+		namespace  = "shoot--foo--bar"
+		identity   = "test"
+		fakeClient client.Client
+		fakeClock  = clock.NewFakeClock(time.Time{})
+
+		m *manager
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetesscheme.Scheme).Build()
+
+		mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+	})
+
+	It("should reuse the persisted snapshot instead of re-deriving bookkeeping from a full listing", func() {
+		By("generating a secret with an explicit rotation initiation time")
+		mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, map[string]time.Time{"config": fakeClock.Now()})
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+
+		_, err = m.Generate(ctx, &secretutils.BasicAuthSecretConfig{
+			Name:           "config",
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "foo",
+			PasswordLength: 3,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		persistedTime := m.lastRotationInitiationTimes["config"]
+
+		By("flushing the snapshot")
+		Expect(m.Flush(ctx)).To(Succeed())
+
+		snapshotConfigMap := &corev1.ConfigMap{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: snapshotConfigMapName(identity)}, snapshotConfigMap)).To(Succeed())
+		Expect(snapshotConfigMap.Data).To(HaveKey(snapshotDataKey))
+
+		By("tampering with the underlying secret's label so that a full listing would disagree with the snapshot")
+		secretList := &corev1.SecretList{}
+		Expect(fakeClient.List(ctx, secretList, client.InNamespace(namespace), client.MatchingLabels{LabelKeyName: "config"})).To(Succeed())
+		Expect(secretList.Items).To(HaveLen(1))
+
+		secret := &secretList.Items[0]
+		patch := client.MergeFrom(secret.DeepCopy())
+		secret.Labels[LabelKeyLastRotationInitiationTime] = "999999"
+		Expect(fakeClient.Patch(ctx, secret, patch)).To(Succeed())
+
+		By("creating a new manager instance")
+		mgr2, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m2 := mgr2.(*manager)
+
+		By("verifying it reused the snapshot's bookkeeping rather than the (tampered) live secret")
+		Expect(m2.lastRotationInitiationTimes["config"]).To(Equal(persistedTime))
+	})
+
+	It("should ignore a snapshot with an incompatible version and fall back to listing secrets", func() {
+		By("generating a secret")
+		_, err := m.Generate(ctx, &secretutils.BasicAuthSecretConfig{
+			Name:           "config",
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "foo",
+			PasswordLength: 3,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("persisting a snapshot ConfigMap with an incompatible version")
+		Expect(fakeClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: snapshotConfigMapName(identity), Namespace: namespace},
+			Data:       map[string]string{snapshotDataKey: `{"version":999,"secrets":{"config":{"lastRotationInitiationTime":"1"}}}`},
+		})).To(Succeed())
+
+		By("creating a new manager instance")
+		mgr2, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m2 := mgr2.(*manager)
+
+		By("verifying the incompatible snapshot was ignored")
+		Expect(m2.lastRotationInitiationTimes["config"]).NotTo(Equal("1"))
+	})
+})
@@ -0,0 +1,78 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gardener/gardener/pkg/utils"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// Verify re-fetches every secret tracked in the internal store from the cluster and compares its data and labels
+// against what was last written by this manager. It does not mutate anything and is meant for drift detection, e.g.
+// to find out whether a secret was manually edited. It returns the sorted names of all secrets whose live state in
+// the cluster diverges from the expected one.
+func (m *manager) Verify(ctx context.Context) ([]string, error) {
+	m.lock.Lock()
+	store := make(secretStore, len(m.store))
+	for key, infos := range m.store {
+		store[key] = infos
+	}
+	m.lock.Unlock()
+
+	var diverged []string
+
+	for key, infos := range store {
+		matches, err := m.matchesLiveState(ctx, infos)
+		if err != nil {
+			return nil, err
+		}
+
+		if !matches {
+			diverged = append(diverged, key.name)
+		}
+	}
+
+	sort.Strings(diverged)
+	return diverged, nil
+}
+
+func (m *manager) matchesLiveState(ctx context.Context, infos secretInfos) (bool, error) {
+	for _, info := range []*secretInfo{&infos.current, infos.old, infos.bundle} {
+		if info == nil {
+			continue
+		}
+
+		liveSecret := &corev1.Secret{}
+		if err := m.client.Get(ctx, kutil.Key(info.obj.Namespace, info.obj.Name), liveSecret); err != nil {
+			return false, err
+		}
+
+		if utils.ComputeSecretChecksum(liveSecret.Data) != info.dataChecksum {
+			return false, nil
+		}
+
+		if !equality.Semantic.DeepEqual(liveSecret.Labels, info.obj.Labels) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Verify", func() {
+	var (
+		ctx        = context.TODO()
+		namespace  = "shoot--foo--bar"
+		identity   = "test"
+		fakeClient client.Client
+		fakeClock  = clock.NewFakeClock(time.Time{})
+
+		m *manager
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetesscheme.Scheme).Build()
+
+		mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+	})
+
+	It("should return no diverged names if nothing was tampered with", func() {
+		_, err := m.Generate(ctx, &secretutils.BasicAuthSecretConfig{
+			Name:           "basic-auth",
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "admin",
+			PasswordLength: 32,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		diverged, err := m.Verify(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diverged).To(BeEmpty())
+	})
+
+	It("should flag exactly the secret whose data was tampered with", func() {
+		secret, err := m.Generate(ctx, &secretutils.BasicAuthSecretConfig{
+			Name:           "basic-auth",
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "admin",
+			PasswordLength: 32,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = m.Generate(ctx, &secretutils.BasicAuthSecretConfig{
+			Name:           "untouched",
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "admin",
+			PasswordLength: 32,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		liveSecret := &corev1.Secret{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), liveSecret)).To(Succeed())
+		patch := client.MergeFrom(liveSecret.DeepCopy())
+		liveSecret.Data["username"] = []byte("tampered")
+		Expect(fakeClient.Patch(ctx, liveSecret, patch)).To(Succeed())
+
+		diverged, err := m.Verify(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diverged).To(ConsistOf("basic-auth"))
+	})
+})
@@ -0,0 +1,63 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConsumersMigratedFunc reports whether every consumer of a KeepOld-rotated secret has adopted the current secret,
+// and it is therefore safe to remove the 'old' one. Implementations typically list their consumers (e.g. Pods) via a
+// client.MatchingLabelsSelector and check that none of them still reference the old secret's name or checksum.
+type ConsumersMigratedFunc func(ctx context.Context) (bool, error)
+
+// WaitForCleanup reports whether the 'old' secret tracked for the secret with the given name has been deleted, and
+// deletes it if migrated reports that every consumer has adopted the current secret. Unlike CompleteRotation, which
+// gates purely on a fixed grace period, readiness here is determined by calling migrated, e.g. a predicate backed by
+// a label selector over the consumers that must have picked up the current secret first. It is a no-op, returning
+// false, if there is no 'old' secret tracked for the given name. If migrated reports false, it instead marks the
+// current secret's LabelKeyRotationPhase as LabelValueRotationPhaseCompleting to record that completion was
+// requested, exactly like CompleteRotation does while waiting out its grace period.
+func (m *manager) WaitForCleanup(ctx context.Context, name string, migrated ConsumersMigratedFunc) (bool, error) {
+	secrets, found := m.getFromStore(m.namespace, name)
+	if !found || secrets.old == nil {
+		return false, nil
+	}
+
+	ok, err := migrated(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if !ok {
+		return false, m.patchRotationPhase(ctx, secrets.current.obj, LabelValueRotationPhaseCompleting)
+	}
+
+	oldSecret := secrets.old.obj
+
+	m.logger.Info("Completing rotation by deleting old secret now that all consumers adopted the current one", "namespace", oldSecret.Namespace, "name", oldSecret.Name)
+	if err := client.IgnoreNotFound(m.client.Delete(ctx, oldSecret)); err != nil {
+		return false, err
+	}
+
+	if err := m.patchRotationPhase(ctx, secrets.current.obj, LabelValueRotationPhaseCompleted); err != nil {
+		return false, err
+	}
+
+	m.removeOldFromStore(m.namespace, name)
+	return true, nil
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/clock"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("WaitForCleanup", func() {
+	const (
+		identity  = "test"
+		namespace = "shoot--foo--bar"
+		name      = "config"
+	)
+
+	var (
+		ctx = context.TODO()
+
+		m          *manager
+		fakeClient client.Client
+		fakeClock  = clock.NewFakeClock(time.Time{})
+
+		config *secretutils.BasicAuthSecretConfig
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetesscheme.Scheme).Build()
+
+		mgr, err := New(ctx, logr.Discard(), fakeClock, fakeClient, namespace, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		m = mgr.(*manager)
+
+		config = &secretutils.BasicAuthSecretConfig{
+			Name:           name,
+			Format:         secretutils.BasicAuthFormatNormal,
+			Username:       "foo",
+			PasswordLength: 3,
+		}
+	})
+
+	It("should be a no-op if there is no old secret tracked", func() {
+		_, err := m.Generate(ctx, config)
+		Expect(err).NotTo(HaveOccurred())
+
+		done, err := m.WaitForCleanup(ctx, name, func(context.Context) (bool, error) { return true, nil })
+		Expect(err).NotTo(HaveOccurred())
+		Expect(done).To(BeFalse())
+	})
+
+	Context("with an old secret tracked from a KeepOld rotation", func() {
+		var oldSecretName string
+
+		BeforeEach(func() {
+			oldSecret, err := m.Generate(ctx, config)
+			Expect(err).NotTo(HaveOccurred())
+			oldSecretName = oldSecret.Name
+
+			config.PasswordLength = 4
+			_, err = m.Generate(ctx, config, Rotate(KeepOld))
+			Expect(err).NotTo(HaveOccurred())
+
+			secretInfos, found := m.getFromStore(namespace, name)
+			Expect(found).To(BeTrue())
+			Expect(secretInfos.old).NotTo(BeNil())
+		})
+
+		It("should keep the old secret and label the current secret with rotation-phase 'completing' while consumers have not migrated", func() {
+			done, err := m.WaitForCleanup(ctx, name, func(context.Context) (bool, error) { return false, nil })
+			Expect(err).NotTo(HaveOccurred())
+			Expect(done).To(BeFalse())
+
+			secretInfos, found := m.getFromStore(namespace, name)
+			Expect(found).To(BeTrue())
+			Expect(secretInfos.old).NotTo(BeNil())
+			Expect(secretInfos.current.obj.Labels).To(HaveKeyWithValue(LabelKeyRotationPhase, LabelValueRotationPhaseCompleting))
+
+			oldSecret := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: oldSecretName}, oldSecret)).To(Succeed())
+		})
+
+		It("should delete the old secret and label the current secret with rotation-phase 'completed' once consumers have migrated", func() {
+			done, err := m.WaitForCleanup(ctx, name, func(context.Context) (bool, error) { return true, nil })
+			Expect(err).NotTo(HaveOccurred())
+			Expect(done).To(BeTrue())
+
+			secretInfos, found := m.getFromStore(namespace, name)
+			Expect(found).To(BeTrue())
+			Expect(secretInfos.old).To(BeNil())
+			Expect(secretInfos.current.obj.Labels).To(HaveKeyWithValue(LabelKeyRotationPhase, LabelValueRotationPhaseCompleted))
+
+			oldSecret := &corev1.Secret{}
+			err = fakeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: oldSecretName}, oldSecret)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should propagate an error from the migrated predicate without mutating anything", func() {
+			fakeErr := fmt.Errorf("could not list consumers")
+			_, err := m.WaitForCleanup(ctx, name, func(context.Context) (bool, error) { return false, fakeErr })
+			Expect(err).To(MatchError(fakeErr))
+
+			secretInfos, found := m.getFromStore(namespace, name)
+			Expect(found).To(BeTrue())
+			Expect(secretInfos.old).NotTo(BeNil())
+		})
+	})
+})
@@ -16,9 +16,13 @@ package secrets
 
 import (
 	"bytes"
-	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"math/big"
 
 	"github.com/gardener/gardener/pkg/utils"
 	"github.com/gardener/gardener/pkg/utils/infodata"
@@ -30,6 +34,13 @@ const (
 	DataKeyRSAPrivateKey = "id_rsa"
 	// DataKeySSHAuthorizedKeys is the key in a secret data holding the OpenSSH authorized keys.
 	DataKeySSHAuthorizedKeys = "id_rsa.pub"
+	// DataKeyServiceAccountJWKS is the key in a secret data holding the JWKS document (RFC 7517) with the public
+	// key(s) corresponding to an RSASecretConfig with IncludeJWKS set.
+	DataKeyServiceAccountJWKS = "jwks.json"
+
+	// MinimumRSAKeyBits is the minimum number of bits an RSASecretConfig accepts for its Bits field unless
+	// AllowInsecureKeySize is set.
+	MinimumRSAKeyBits = 2048
 )
 
 // RSASecretConfig containing information about the number of bits which should be used for the to-be-created RSA private key.
@@ -38,6 +49,32 @@ type RSASecretConfig struct {
 
 	Bits       int
 	UsedForSSH bool
+
+	// AllowInsecureKeySize allows generating a key with fewer than MinimumRSAKeyBits bits. This is only meant for
+	// legacy secrets that cannot yet be rotated to a larger key size and must not be used for new secrets.
+	AllowInsecureKeySize bool
+
+	// PKCS selects the PEM encoding of the generated private key: PKCS1 (the default) or PKCS8, e.g. for consumers
+	// (Java, certain TLS libraries) that require PKCS8-encoded keys. See the same-named field on
+	// CertificateSecretConfig.
+	PKCS int
+
+	// PrivateKeyDataKey overrides the data key under which the PEM-encoded private key is stored. Defaults to
+	// DataKeyRSAPrivateKey. Set it to corev1.SSHAuthPrivateKey ("ssh-privatekey") together with UsedForSSH so the
+	// resulting secret is recognized as a kubernetes.io/ssh-auth secret.
+	PrivateKeyDataKey string
+	// PublicKeyDataKey overrides the data key under which the OpenSSH authorized key is stored when UsedForSSH is
+	// set. Defaults to DataKeySSHAuthorizedKeys.
+	PublicKeyDataKey string
+
+	// IncludeJWKS specifies whether a JWKS document (RFC 7517) containing the public key should additionally be
+	// stored under DataKeyServiceAccountJWKS, e.g. for components validating tokens signed by this key via OIDC
+	// discovery. Each key in the document is assigned a stable 'kid' derived from a hash of its DER-encoded form.
+	IncludeJWKS bool
+	// RetainedPublicKeys are additional public keys to include in the JWKS document alongside the freshly generated
+	// key, e.g. the previous key while it is kept around during a Rotate(KeepOld) rotation so that tokens signed
+	// with it keep validating. Only considered if IncludeJWKS is set.
+	RetainedPublicKeys []*rsa.PublicKey
 }
 
 // RSAKeys contains the private key, the public key, and optionally the OpenSSH-formatted authorized keys file data.
@@ -48,6 +85,18 @@ type RSAKeys struct {
 	PublicKey  *rsa.PublicKey
 
 	OpenSSHAuthorizedKey []byte
+
+	// PrivateKeyDataKey is the data key under which PrivateKey is stored. Defaults to DataKeyRSAPrivateKey.
+	PrivateKeyDataKey string
+	// PublicKeyDataKey is the data key under which OpenSSHAuthorizedKey is stored. Defaults to
+	// DataKeySSHAuthorizedKeys.
+	PublicKeyDataKey string
+
+	// JWKS is the JSON-encoded JWKS document (see RSASecretConfig.IncludeJWKS), or nil if it was not requested.
+	JWKS []byte
+
+	// PrivateKeyPEM is the PEM encoding of PrivateKey, per RSASecretConfig.PKCS.
+	PrivateKeyPEM []byte
 }
 
 // GetName returns the name of the secret.
@@ -62,7 +111,11 @@ func (s *RSASecretConfig) Generate() (DataInterface, error) {
 
 // GenerateInfoData implements ConfigInterface.
 func (s *RSASecretConfig) GenerateInfoData() (infodata.InfoData, error) {
-	privateKey, err := GenerateKey(rand.Reader, s.Bits)
+	if err := s.validateBits(); err != nil {
+		return nil, err
+	}
+
+	privateKey, err := GenerateKey(utils.RandSource, s.Bits)
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +146,11 @@ func (s *RSASecretConfig) LoadFromSecretData(secretData map[string][]byte) (info
 
 // GenerateRSAKeys computes a RSA private key based on the configured number of bits.
 func (s *RSASecretConfig) GenerateRSAKeys() (*RSAKeys, error) {
-	privateKey, err := GenerateKey(rand.Reader, s.Bits)
+	if err := s.validateBits(); err != nil {
+		return nil, err
+	}
+
+	privateKey, err := GenerateKey(utils.RandSource, s.Bits)
 	if err != nil {
 		return nil, err
 	}
@@ -101,38 +158,117 @@ func (s *RSASecretConfig) GenerateRSAKeys() (*RSAKeys, error) {
 	return s.generateWithPrivateKey(privateKey)
 }
 
+// validateBits rejects RSA key sizes below MinimumRSAKeyBits unless AllowInsecureKeySize is set.
+func (s *RSASecretConfig) validateBits() error {
+	if s.Bits < MinimumRSAKeyBits && !s.AllowInsecureKeySize {
+		return fmt.Errorf("refusing to generate RSA key %q with %d bits: minimum is %d bits (set AllowInsecureKeySize to override)", s.Name, s.Bits, MinimumRSAKeyBits)
+	}
+	return nil
+}
+
 func (s *RSASecretConfig) generateWithPrivateKey(privateKey *rsa.PrivateKey) (*RSAKeys, error) {
-	rsa := &RSAKeys{
+	privateKeyPEM, err := encodeRSAPrivateKey(s.PKCS, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &RSAKeys{
 		Name: s.Name,
 
-		PrivateKey: privateKey,
-		PublicKey:  &privateKey.PublicKey,
+		PrivateKey:    privateKey,
+		PublicKey:     &privateKey.PublicKey,
+		PrivateKeyPEM: privateKeyPEM,
+
+		PrivateKeyDataKey: s.PrivateKeyDataKey,
+		PublicKeyDataKey:  s.PublicKeyDataKey,
 	}
 
 	if s.UsedForSSH {
-		sshPublicKey, err := generateSSHAuthorizedKeys(rsa.PrivateKey)
+		sshPublicKey, err := generateSSHAuthorizedKeys(keys.PrivateKey)
 		if err != nil {
 			return nil, err
 		}
-		rsa.OpenSSHAuthorizedKey = sshPublicKey
+		keys.OpenSSHAuthorizedKey = sshPublicKey
 	}
 
-	return rsa, nil
+	if s.IncludeJWKS {
+		jwks, err := jwksForRSAPublicKeys(append([]*rsa.PublicKey{&privateKey.PublicKey}, s.RetainedPublicKeys...))
+		if err != nil {
+			return nil, err
+		}
+		keys.JWKS = jwks
+	}
+
+	return keys, nil
 }
 
 // SecretData computes the data map which can be used in a Kubernetes secret.
 func (r *RSAKeys) SecretData() map[string][]byte {
+	privateKeyDataKey := r.PrivateKeyDataKey
+	if privateKeyDataKey == "" {
+		privateKeyDataKey = DataKeyRSAPrivateKey
+	}
+
 	data := map[string][]byte{
-		DataKeyRSAPrivateKey: utils.EncodePrivateKey(r.PrivateKey),
+		privateKeyDataKey: r.PrivateKeyPEM,
 	}
 
 	if r.OpenSSHAuthorizedKey != nil {
-		data[DataKeySSHAuthorizedKeys] = r.OpenSSHAuthorizedKey
+		publicKeyDataKey := r.PublicKeyDataKey
+		if publicKeyDataKey == "" {
+			publicKeyDataKey = DataKeySSHAuthorizedKeys
+		}
+		data[publicKeyDataKey] = r.OpenSSHAuthorizedKey
+	}
+
+	if r.JWKS != nil {
+		data[DataKeyServiceAccountJWKS] = r.JWKS
 	}
 
 	return data
 }
 
+// jsonWebKey is a minimal representation of an RSA public key in JWK format (RFC 7517).
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// jsonWebKeySet is a minimal representation of a JWKS document (RFC 7517).
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// jwksForRSAPublicKeys builds a JSON-encoded JWKS document containing the given RSA public keys. Each key is
+// assigned a stable 'kid' derived from the SHA-256 hash of its DER-encoded (SubjectPublicKeyInfo) form, so that the
+// same key always maps to the same 'kid' regardless of how many times it is re-serialized.
+func jwksForRSAPublicKeys(keys []*rsa.PublicKey) ([]byte, error) {
+	jwks := jsonWebKeySet{Keys: make([]jsonWebKey, 0, len(keys))}
+
+	for _, key := range keys {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			return nil, err
+		}
+		kid := sha256.Sum256(der)
+
+		jwks.Keys = append(jwks.Keys, jsonWebKey{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: "RS256",
+			Kid: base64.RawURLEncoding.EncodeToString(kid[:]),
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+
+	return json.Marshal(jwks)
+}
+
 // generateSSHAuthorizedKeys takes a RSA private key <privateKey> and generates the corresponding public key.
 // It serializes the public key for inclusion in an OpenSSH `authorized_keys` file and it trims the new-
 // line at the end.
@@ -17,6 +17,10 @@ package secrets_test
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
 
 	"github.com/gardener/gardener/pkg/utils"
 
@@ -34,8 +38,9 @@ var _ = Describe("RSA Private Key Secrets", func() {
 
 		BeforeEach(func() {
 			rsaPrivateKeyConfig = &RSASecretConfig{
-				Bits: 16,
-				Name: "rsa-secret",
+				Bits:                 16,
+				Name:                 "rsa-secret",
+				AllowInsecureKeySize: true,
 			}
 			rsaInfoData = &PrivateKeyInfoData{
 				PrivateKey: []byte("foo"),
@@ -63,6 +68,104 @@ var _ = Describe("RSA Private Key Secrets", func() {
 				Expect(ok).To(BeTrue())
 				Expect(rsaSecret.OpenSSHAuthorizedKey).NotTo(BeNil())
 			})
+			It("should generate a JWKS document containing the public key if IncludeJWKS is set", func() {
+				rsaPrivateKeyConfig.IncludeJWKS = true
+				obj, err := rsaPrivateKeyConfig.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				rsaSecret, ok := obj.(*RSAKeys)
+				Expect(ok).To(BeTrue())
+				Expect(rsaSecret.JWKS).NotTo(BeNil())
+
+				jwks := struct {
+					Keys []struct {
+						Kid string `json:"kid"`
+						N   string `json:"n"`
+					} `json:"keys"`
+				}{}
+				Expect(json.Unmarshal(rsaSecret.JWKS, &jwks)).To(Succeed())
+				Expect(jwks.Keys).To(HaveLen(1))
+				Expect(jwks.Keys[0].Kid).NotTo(BeEmpty())
+				Expect(jwks.Keys[0].N).To(Equal(base64.RawURLEncoding.EncodeToString(rsaSecret.PublicKey.N.Bytes())))
+			})
+
+			It("should include retained public keys in the JWKS document alongside the freshly generated one", func() {
+				oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+				Expect(err).NotTo(HaveOccurred())
+
+				rsaPrivateKeyConfig.IncludeJWKS = true
+				rsaPrivateKeyConfig.RetainedPublicKeys = []*rsa.PublicKey{&oldKey.PublicKey}
+
+				obj, err := rsaPrivateKeyConfig.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				rsaSecret, ok := obj.(*RSAKeys)
+				Expect(ok).To(BeTrue())
+
+				jwks := struct {
+					Keys []struct {
+						Kid string `json:"kid"`
+						N   string `json:"n"`
+					} `json:"keys"`
+				}{}
+				Expect(json.Unmarshal(rsaSecret.JWKS, &jwks)).To(Succeed())
+				Expect(jwks.Keys).To(HaveLen(2))
+				Expect(jwks.Keys[0].N).To(Equal(base64.RawURLEncoding.EncodeToString(rsaSecret.PublicKey.N.Bytes())))
+				Expect(jwks.Keys[1].N).To(Equal(base64.RawURLEncoding.EncodeToString(oldKey.N.Bytes())))
+				Expect(jwks.Keys[0].Kid).NotTo(Equal(jwks.Keys[1].Kid))
+			})
+			It("should refuse to generate a key below the minimum size by default", func() {
+				rsaPrivateKeyConfig.Bits = 512
+				rsaPrivateKeyConfig.AllowInsecureKeySize = false
+
+				_, err := rsaPrivateKeyConfig.Generate()
+				Expect(err).To(MatchError(ContainSubstring("minimum is 2048 bits")))
+			})
+			It("should generate a key below the minimum size when AllowInsecureKeySize is set", func() {
+				rsaPrivateKeyConfig.Bits = 512
+				rsaPrivateKeyConfig.AllowInsecureKeySize = true
+
+				obj, err := rsaPrivateKeyConfig.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				rsaSecret, ok := obj.(*RSAKeys)
+				Expect(ok).To(BeTrue())
+				Expect(rsaSecret.PrivateKey).NotTo(BeNil())
+			})
+
+			It("should PEM-encode the private key as PKCS1 by default", func() {
+				obj, err := rsaPrivateKeyConfig.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				rsaSecret, ok := obj.(*RSAKeys)
+				Expect(ok).To(BeTrue())
+
+				block, _ := pem.Decode(rsaSecret.PrivateKeyPEM)
+				Expect(block).NotTo(BeNil())
+
+				parsedKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parsedKey).To(Equal(rsaSecret.PrivateKey))
+			})
+
+			It("should PEM-encode the private key as PKCS8 when PKCS is set to PKCS8", func() {
+				rsaPrivateKeyConfig.PKCS = PKCS8
+
+				obj, err := rsaPrivateKeyConfig.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				rsaSecret, ok := obj.(*RSAKeys)
+				Expect(ok).To(BeTrue())
+
+				block, _ := pem.Decode(rsaSecret.PrivateKeyPEM)
+				Expect(block).NotTo(BeNil())
+
+				parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parsedKey).To(Equal(rsaSecret.PrivateKey))
+
+				Expect(rsaSecret.SecretData()[DataKeyRSAPrivateKey]).To(Equal(rsaSecret.PrivateKeyPEM))
+			})
 		})
 
 		Describe("#GenerateInfoData", func() {
@@ -127,6 +230,7 @@ var _ = Describe("RSA Private Key Secrets", func() {
 
 			rsaKeys = &RSAKeys{
 				PrivateKey:           key,
+				PrivateKeyPEM:        utils.EncodePrivateKey(key),
 				OpenSSHAuthorizedKey: []byte("bar"),
 			}
 		})
@@ -139,6 +243,23 @@ var _ = Describe("RSA Private Key Secrets", func() {
 				}
 				Expect(rsaKeys.SecretData()).To(Equal(secretData))
 			})
+
+			It("should use the configured data keys when set", func() {
+				rsaKeys.PrivateKeyDataKey = "ssh-privatekey"
+				rsaKeys.PublicKeyDataKey = "ssh-publickey"
+
+				secretData := map[string][]byte{
+					"ssh-privatekey": utils.EncodePrivateKey(key),
+					"ssh-publickey":  []byte("bar"),
+				}
+				Expect(rsaKeys.SecretData()).To(Equal(secretData))
+			})
+
+			It("should include the JWKS document when set", func() {
+				rsaKeys.JWKS = []byte(`{"keys":[]}`)
+
+				Expect(rsaKeys.SecretData()).To(HaveKeyWithValue(DataKeyServiceAccountJWKS, rsaKeys.JWKS))
+			})
 		})
 	})
 })
@@ -19,14 +19,28 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/gardener/gardener/pkg/utils"
 	"github.com/gardener/gardener/pkg/utils/infodata"
+
+	"sigs.k8s.io/yaml"
 )
 
 const (
 	// DataKeyStaticTokenCSV is the key in a secret data holding the CSV format of a secret.
 	DataKeyStaticTokenCSV = "static_tokens.csv"
+	// DataKeyStaticTokenHashedCSV is the key in a secret data holding the CSV format of a secret with hashed tokens,
+	// bootstrap-token-style, in the '<hash-algo>:<hex-digest>' notation. It has the same row layout as
+	// DataKeyStaticTokenCSV so that consumers can look up a username/userID/groups by matching hash instead of by
+	// cleartext token.
+	DataKeyStaticTokenHashedCSV = "static_tokens_hashed.csv"
+	// DataKeyStaticTokenMembers is the key in a secret data holding a YAML list of StaticTokenMember entries, i.e.
+	// the configured usernames/userIDs/groups without their token values, so that auditing tools can inspect
+	// membership without needing access to the secret's raw tokens.
+	DataKeyStaticTokenMembers = "static_tokens_members.yaml"
 	// DataKeyToken is the key in a secret data holding the token.
 	DataKeyToken = "token"
+
+	tokenHashAlgorithm = "sha256"
 )
 
 // StaticTokenSecretConfig contains the specification a to-be-generated static token secret.
@@ -34,6 +48,27 @@ type StaticTokenSecretConfig struct {
 	Name string
 
 	Tokens map[string]TokenConfig
+
+	// AddHashedTokens specifies whether the hashed representation of the generated tokens should additionally be
+	// stored in the secret under DataKeyStaticTokenHashedCSV, so that consumers can choose between raw and hashed
+	// tokens without regenerating the secret.
+	AddHashedTokens bool
+
+	// AddMembers specifies whether a structured, token-free representation of the configured users/groups should
+	// additionally be stored in the secret under DataKeyStaticTokenMembers, so that auditing tools can inspect
+	// membership without needing access to the raw tokens.
+	AddMembers bool
+}
+
+// StaticTokenMember is the token-free representation of a single configured user, as stored under
+// DataKeyStaticTokenMembers.
+type StaticTokenMember struct {
+	// Username is the user name associated with the token.
+	Username string `json:"username"`
+	// UserID is the user id associated with the token.
+	UserID string `json:"userID,omitempty"`
+	// Groups are the groups associated with the token.
+	Groups []string `json:"groups,omitempty"`
 }
 
 // TokenConfig contains configuration for a token.
@@ -48,6 +83,14 @@ type StaticToken struct {
 	Name string
 
 	Tokens []Token
+
+	// AddHashedTokens specifies whether SecretData should additionally emit the hashed representation of the tokens
+	// under DataKeyStaticTokenHashedCSV. See StaticTokenSecretConfig.AddHashedTokens for details.
+	AddHashedTokens bool
+
+	// MembersYAML, if non-nil, is additionally stored in the secret under DataKeyStaticTokenMembers by SecretData.
+	// See StaticTokenSecretConfig.AddMembers for details.
+	MembersYAML []byte
 }
 
 // Token contains fields of a generated token.
@@ -106,9 +149,16 @@ func (s *StaticTokenSecretConfig) GenerateFromInfoData(infoData infodata.InfoDat
 		})
 	}
 
+	membersYAML, err := s.membersYAML(tokens)
+	if err != nil {
+		return nil, err
+	}
+
 	return &StaticToken{
-		Name:   s.Name,
-		Tokens: tokens,
+		Name:            s.Name,
+		Tokens:          tokens,
+		AddHashedTokens: s.AddHashedTokens,
+		MembersYAML:     membersYAML,
 	}, nil
 }
 
@@ -145,17 +195,44 @@ func (s *StaticTokenSecretConfig) GenerateStaticToken() (*StaticToken, error) {
 		})
 	}
 
+	membersYAML, err := s.membersYAML(tokens)
+	if err != nil {
+		return nil, err
+	}
+
 	return &StaticToken{
-		Name:   s.Name,
-		Tokens: tokens,
+		Name:            s.Name,
+		Tokens:          tokens,
+		AddHashedTokens: s.AddHashedTokens,
+		MembersYAML:     membersYAML,
 	}, nil
 }
 
+// membersYAML returns the YAML-serialized, token-free representation of tokens to be stored under
+// DataKeyStaticTokenMembers, or nil if AddMembers is not set.
+func (s *StaticTokenSecretConfig) membersYAML(tokens []Token) ([]byte, error) {
+	if !s.AddMembers {
+		return nil, nil
+	}
+
+	members := make([]StaticTokenMember, 0, len(tokens))
+	for _, token := range tokens {
+		members = append(members, StaticTokenMember{
+			Username: token.Username,
+			UserID:   token.UserID,
+			Groups:   token.Groups,
+		})
+	}
+
+	return yaml.Marshal(members)
+}
+
 // SecretData computes the data map which can be used in a Kubernetes secret.
 func (b *StaticToken) SecretData() map[string][]byte {
 	var (
-		data   = make(map[string][]byte, 1)
-		tokens = make([]string, 0, len(b.Tokens))
+		data         = make(map[string][]byte, 1)
+		tokens       = make([]string, 0, len(b.Tokens))
+		hashedTokens = make([]string, 0, len(b.Tokens))
 	)
 
 	for _, token := range b.Tokens {
@@ -164,12 +241,27 @@ func (b *StaticToken) SecretData() map[string][]byte {
 			groups = fmt.Sprintf("%q", groups)
 		}
 		tokens = append(tokens, fmt.Sprintf("%s,%s,%s,%s", token.Token, token.Username, token.UserID, groups))
+
+		if b.AddHashedTokens {
+			hashedTokens = append(hashedTokens, fmt.Sprintf("%s,%s,%s,%s", hashToken(token.Token), token.Username, token.UserID, groups))
+		}
 	}
 
 	data[DataKeyStaticTokenCSV] = []byte(strings.Join(tokens, "\n"))
+	if b.AddHashedTokens {
+		data[DataKeyStaticTokenHashedCSV] = []byte(strings.Join(hashedTokens, "\n"))
+	}
+	if b.MembersYAML != nil {
+		data[DataKeyStaticTokenMembers] = b.MembersYAML
+	}
 	return data
 }
 
+// hashToken computes the bootstrap-token-style hash of the given token in the '<hash-algo>:<hex-digest>' notation.
+func hashToken(token string) string {
+	return fmt.Sprintf("%s:%s", tokenHashAlgorithm, utils.ComputeSHA256Hex([]byte(token)))
+}
+
 // GetTokenForUsername returns the token for the given username.
 func (b *StaticToken) GetTokenForUsername(username string) (*Token, error) {
 	for _, token := range b.Tokens {
@@ -15,10 +15,14 @@
 package secrets_test
 
 import (
+	"strings"
+
+	"github.com/gardener/gardener/pkg/utils"
 	. "github.com/gardener/gardener/pkg/utils/secrets"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
 )
 
 var _ = Describe("Static Token Secrets", func() {
@@ -109,6 +113,71 @@ var _ = Describe("Static Token Secrets", func() {
 				Expect(currentStaticTokenInfoData).To(Equal(staticTokenInfoData))
 			})
 		})
+
+		Describe("#Generate with AddHashedTokens", func() {
+			It("should additionally emit a hashed representation consistent with the cleartext tokens", func() {
+				staticTokenConfig.AddHashedTokens = true
+
+				obj, err := staticTokenConfig.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				staticToken, ok := obj.(*StaticToken)
+				Expect(ok).To(BeTrue())
+
+				data := staticToken.SecretData()
+				Expect(data).To(HaveKey(DataKeyStaticTokenCSV))
+				Expect(data).To(HaveKey(DataKeyStaticTokenHashedCSV))
+
+				cleartextLines := strings.Split(string(data[DataKeyStaticTokenCSV]), "\n")
+				hashedLines := strings.Split(string(data[DataKeyStaticTokenHashedCSV]), "\n")
+				Expect(hashedLines).To(HaveLen(len(cleartextLines)))
+
+				for i, line := range cleartextLines {
+					cleartextToken, err := LoadStaticTokenFromCSV("static-token", []byte(line))
+					Expect(err).NotTo(HaveOccurred())
+
+					hashedToken, err := LoadStaticTokenFromCSV("static-token", []byte(hashedLines[i]))
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(hashedToken.Tokens[0].Token).To(Equal("sha256:" + utils.ComputeSHA256Hex([]byte(cleartextToken.Tokens[0].Token))))
+					Expect(hashedToken.Tokens[0].Username).To(Equal(cleartextToken.Tokens[0].Username))
+				}
+			})
+		})
+
+		Describe("#Generate with AddMembers", func() {
+			It("should additionally emit a structured, token-free list of the configured users/groups", func() {
+				staticTokenConfig.AddMembers = true
+
+				obj, err := staticTokenConfig.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				staticToken, ok := obj.(*StaticToken)
+				Expect(ok).To(BeTrue())
+
+				data := staticToken.SecretData()
+				Expect(data).To(HaveKey(DataKeyStaticTokenMembers))
+				Expect(data[DataKeyStaticTokenMembers]).NotTo(ContainSubstring(staticToken.Tokens[0].Token))
+
+				var members []StaticTokenMember
+				Expect(yaml.Unmarshal(data[DataKeyStaticTokenMembers], &members)).To(Succeed())
+				Expect(members).To(ConsistOf(StaticTokenMember{
+					Username: staticTokenConfig.Tokens[username].Username,
+					UserID:   staticTokenConfig.Tokens[username].UserID,
+					Groups:   staticTokenConfig.Tokens[username].Groups,
+				}))
+			})
+
+			It("should not emit the members data key if AddMembers is not set", func() {
+				obj, err := staticTokenConfig.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				staticToken, ok := obj.(*StaticToken)
+				Expect(ok).To(BeTrue())
+
+				Expect(staticToken.SecretData()).NotTo(HaveKey(DataKeyStaticTokenMembers))
+			})
+		})
 	})
 
 	Describe("StaticToken Object", func() {
@@ -134,6 +203,26 @@ var _ = Describe("Static Token Secrets", func() {
 				}
 				Expect(staticToken.SecretData()).To(Equal(secretData))
 			})
+
+			It("should additionally return hashed secret data if AddHashedTokens is set", func() {
+				staticToken.AddHashedTokens = true
+
+				secretData := map[string][]byte{
+					DataKeyStaticTokenCSV:       []byte("foo,foo,bar,group"),
+					DataKeyStaticTokenHashedCSV: []byte("sha256:" + utils.ComputeSHA256Hex([]byte("foo")) + ",foo,bar,group"),
+				}
+				Expect(staticToken.SecretData()).To(Equal(secretData))
+			})
+
+			It("should additionally return the members data if MembersYAML is set", func() {
+				staticToken.MembersYAML = []byte("- username: foo\n")
+
+				secretData := map[string][]byte{
+					DataKeyStaticTokenCSV:     []byte("foo,foo,bar,group"),
+					DataKeyStaticTokenMembers: []byte("- username: foo\n"),
+				}
+				Expect(staticToken.SecretData()).To(Equal(secretData))
+			})
 		})
 	})
 })
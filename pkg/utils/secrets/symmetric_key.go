@@ -0,0 +1,120 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"fmt"
+
+	"github.com/gardener/gardener/pkg/utils/infodata"
+)
+
+// DataKeyPreSharedKey is the default key in a secret data holding a generated symmetric key.
+const DataKeyPreSharedKey = "psk"
+
+// SymmetricKeySecretConfig contains the specification for a to-be-generated symmetric shared key, e.g. a VPN
+// pre-shared key. The key is a random string of the configured Size, generated via GenerateRandomString.
+type SymmetricKeySecretConfig struct {
+	Name string
+
+	// Size is the length (in characters) of the generated key.
+	Size int
+
+	// DataKey overrides the key under which the generated key is stored in the secret. Defaults to
+	// DataKeyPreSharedKey.
+	DataKey string
+}
+
+// SymmetricKey contains the name and the generated symmetric key.
+type SymmetricKey struct {
+	Name string
+	Key  string
+
+	// DataKey is the data key under which Key is stored. Defaults to DataKeyPreSharedKey.
+	DataKey string
+}
+
+// GetName returns the name of the secret.
+func (s *SymmetricKeySecretConfig) GetName() string {
+	return s.Name
+}
+
+// Generate implements ConfigInterface.
+func (s *SymmetricKeySecretConfig) Generate() (DataInterface, error) {
+	key, err := s.generateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SymmetricKey{
+		Name:    s.Name,
+		Key:     key,
+		DataKey: s.DataKey,
+	}, nil
+}
+
+// GenerateInfoData implements ConfigInterface.
+func (s *SymmetricKeySecretConfig) GenerateInfoData() (infodata.InfoData, error) {
+	key, err := s.generateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPrivateKeyInfoData([]byte(key)), nil
+}
+
+// GenerateFromInfoData implements ConfigInterface.
+func (s *SymmetricKeySecretConfig) GenerateFromInfoData(infoData infodata.InfoData) (DataInterface, error) {
+	data, ok := infoData.(*PrivateKeyInfoData)
+	if !ok {
+		return nil, fmt.Errorf("could not convert InfoData entry %s to PrivateKeyInfoData", s.Name)
+	}
+
+	return &SymmetricKey{
+		Name:    s.Name,
+		Key:     string(data.PrivateKey),
+		DataKey: s.DataKey,
+	}, nil
+}
+
+// LoadFromSecretData implements infodata.Loader.
+func (s *SymmetricKeySecretConfig) LoadFromSecretData(secretData map[string][]byte) (infodata.InfoData, error) {
+	return NewPrivateKeyInfoData(secretData[s.dataKey()]), nil
+}
+
+func (s *SymmetricKeySecretConfig) dataKey() string {
+	if s.DataKey != "" {
+		return s.DataKey
+	}
+	return DataKeyPreSharedKey
+}
+
+func (s *SymmetricKeySecretConfig) generateKey() (string, error) {
+	if s.Size <= 0 {
+		return "", fmt.Errorf("key size must be a positive number, got %d", s.Size)
+	}
+	return GenerateRandomString(s.Size)
+}
+
+// SecretData computes the data map which can be used in a Kubernetes secret.
+func (k *SymmetricKey) SecretData() map[string][]byte {
+	dataKey := k.DataKey
+	if dataKey == "" {
+		dataKey = DataKeyPreSharedKey
+	}
+
+	return map[string][]byte{
+		dataKey: []byte(k.Key),
+	}
+}
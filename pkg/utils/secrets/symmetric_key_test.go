@@ -0,0 +1,136 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets_test
+
+import (
+	. "github.com/gardener/gardener/pkg/utils/secrets"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Symmetric Key Secrets", func() {
+	Describe("Symmetric Key Secret Configuration", func() {
+		var (
+			symmetricKeyConfig *SymmetricKeySecretConfig
+			symmetricInfoData  *PrivateKeyInfoData
+		)
+
+		BeforeEach(func() {
+			symmetricKeyConfig = &SymmetricKeySecretConfig{
+				Name: "symmetric-key-secret",
+				Size: 32,
+			}
+			symmetricInfoData = &PrivateKeyInfoData{
+				PrivateKey: []byte("foo"),
+			}
+		})
+
+		Describe("#Generate", func() {
+			It("should generate a key of the configured size", func() {
+				obj, err := symmetricKeyConfig.Generate()
+				Expect(err).NotTo(HaveOccurred())
+
+				symmetricKey, ok := obj.(*SymmetricKey)
+				Expect(ok).To(BeTrue())
+				Expect(symmetricKey.Key).To(HaveLen(32))
+			})
+
+			It("should return an error if Size is zero", func() {
+				symmetricKeyConfig.Size = 0
+				_, err := symmetricKeyConfig.Generate()
+				Expect(err).To(MatchError(ContainSubstring("key size must be a positive number")))
+			})
+
+			It("should return an error if Size is negative", func() {
+				symmetricKeyConfig.Size = -1
+				_, err := symmetricKeyConfig.Generate()
+				Expect(err).To(MatchError(ContainSubstring("key size must be a positive number")))
+			})
+		})
+
+		Describe("#GenerateInfoData", func() {
+			It("should generate correct PrivateKey InfoData", func() {
+				obj, err := symmetricKeyConfig.GenerateInfoData()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(obj.TypeVersion()).To(Equal(PrivateKeyDataType))
+
+				infoData, ok := obj.(*PrivateKeyInfoData)
+				Expect(ok).To(BeTrue())
+				Expect(infoData.PrivateKey).To(HaveLen(32))
+			})
+		})
+
+		Describe("#GenerateFromInfoData", func() {
+			It("should deterministically reproduce the same key from the same InfoData", func() {
+				obj, err := symmetricKeyConfig.GenerateFromInfoData(symmetricInfoData)
+				Expect(err).NotTo(HaveOccurred())
+
+				symmetricKey, ok := obj.(*SymmetricKey)
+				Expect(ok).To(BeTrue())
+				Expect(symmetricKey.Key).To(Equal("foo"))
+
+				again, err := symmetricKeyConfig.GenerateFromInfoData(symmetricInfoData)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(again).To(Equal(symmetricKey))
+			})
+		})
+
+		Describe("#LoadFromSecretData", func() {
+			It("should properly load PrivateKeyInfoData from secret data using the default data key", func() {
+				secretData := map[string][]byte{
+					DataKeyPreSharedKey: []byte("foo"),
+				}
+				obj, err := symmetricKeyConfig.LoadFromSecretData(secretData)
+				Expect(err).NotTo(HaveOccurred())
+
+				loaded, ok := obj.(*PrivateKeyInfoData)
+				Expect(ok).To(BeTrue())
+				Expect(loaded).To(Equal(symmetricInfoData))
+			})
+
+			It("should properly load PrivateKeyInfoData from secret data using a configured data key", func() {
+				symmetricKeyConfig.DataKey = "shared.key"
+				secretData := map[string][]byte{
+					"shared.key": []byte("foo"),
+				}
+				obj, err := symmetricKeyConfig.LoadFromSecretData(secretData)
+				Expect(err).NotTo(HaveOccurred())
+
+				loaded, ok := obj.(*PrivateKeyInfoData)
+				Expect(ok).To(BeTrue())
+				Expect(loaded).To(Equal(symmetricInfoData))
+			})
+		})
+	})
+
+	Describe("SymmetricKey Object", func() {
+		Describe("#SecretData", func() {
+			It("should store the key under the default data key", func() {
+				symmetricKey := &SymmetricKey{Key: "foo"}
+				Expect(symmetricKey.SecretData()).To(Equal(map[string][]byte{
+					DataKeyPreSharedKey: []byte("foo"),
+				}))
+			})
+
+			It("should store the key under the configured data key", func() {
+				symmetricKey := &SymmetricKey{Key: "foo", DataKey: "shared.key"}
+				Expect(symmetricKey.SecretData()).To(Equal(map[string][]byte{
+					"shared.key": []byte("foo"),
+				}))
+			})
+		})
+	})
+})